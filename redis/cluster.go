@@ -7,6 +7,8 @@ import (
 	"math"
 	"math/rand"
 	"net"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -37,14 +39,33 @@ type ClusterOptions struct {
 	RouteByLatency bool
 	// Allows routing read-only commands to the random master or slave node.
 	RouteRandomly bool
+	// Picks the node that serves a read-only command. Takes precedence
+	// over RouteByLatency and RouteRandomly and implies ReadOnly.
+	NodeSelector NodeSelector
+
+	// Frequency of background cluster state reloads.
+	// Default is to reload state only after MOVED redirects and errors.
+	StateReloadFrequency time.Duration
+	// Number of nodes asked for cluster state on every reload. The view
+	// reported by the most nodes is used, so a single node with a stale
+	// view does not redirect the client.
+	// Default is 1 (the first node that replies).
+	StateConsensus int
+	// Builds cluster state from CLUSTER NODES instead of CLUSTER SLOTS.
+	// Unlike CLUSTER SLOTS it reports node flags and link state, so
+	// failing slaves are excluded from routing read-only commands.
+	UseClusterNodes bool
 
 	// Following options are copied from Options struct.
 
 	OnConnect func(*Conn) error
 
+	CredentialsProvider func() (username string, password string, err error)
+
 	MaxRetries      int
 	MinRetryBackoff time.Duration
 	MaxRetryBackoff time.Duration
+	Username        string
 	Password        string
 
 	DialTimeout  time.Duration
@@ -56,6 +77,8 @@ type ClusterOptions struct {
 	PoolTimeout        time.Duration
 	IdleTimeout        time.Duration
 	IdleCheckFrequency time.Duration
+
+	PubSubHealthCheckFrequency time.Duration
 }
 
 func (opt *ClusterOptions) init() {
@@ -65,10 +88,14 @@ func (opt *ClusterOptions) init() {
 		opt.MaxRedirects = 8
 	}
 
-	if opt.RouteByLatency {
+	if opt.RouteByLatency || opt.NodeSelector != nil {
 		opt.ReadOnly = true
 	}
 
+	if opt.StateConsensus <= 0 {
+		opt.StateConsensus = 1
+	}
+
 	switch opt.ReadTimeout {
 	case -1:
 		opt.ReadTimeout = 0
@@ -102,9 +129,12 @@ func (opt *ClusterOptions) clientOptions() *Options {
 	return &Options{
 		OnConnect: opt.OnConnect,
 
+		CredentialsProvider: opt.CredentialsProvider,
+
 		MaxRetries:      opt.MaxRetries,
 		MinRetryBackoff: opt.MinRetryBackoff,
 		MaxRetryBackoff: opt.MaxRetryBackoff,
+		Username:        opt.Username,
 		Password:        opt.Password,
 		readOnly:        opt.ReadOnly,
 
@@ -117,11 +147,45 @@ func (opt *ClusterOptions) clientOptions() *Options {
 		IdleTimeout: opt.IdleTimeout,
 
 		IdleCheckFrequency: disableIdleCheck,
+
+		PubSubHealthCheckFrequency: opt.PubSubHealthCheckFrequency,
 	}
 }
 
 //------------------------------------------------------------------------------
 
+// ClusterSlotNode describes a node serving a slot as seen by NodeSelector.
+type ClusterSlotNode struct {
+	Addr string
+	// Master is true for the slot master, which is always the first node.
+	Master bool
+	// Average PING round trip measured when the node is added to the
+	// cluster state. Very large until the measurement completes.
+	Latency time.Duration
+	// Loading is true if the node recently replied with LOADING error.
+	Loading bool
+}
+
+// NodeSelector returns the index of the node that should serve
+// a read-only command for the slot. nodes[0] is the slot master and the
+// rest are its slaves. Out of range index selects the master.
+type NodeSelector func(slot int, nodes []ClusterSlotNode) int
+
+// PreferSlaveSelector picks a random slave that is not loading and falls
+// back to the master when there is none.
+func PreferSlaveSelector(slot int, nodes []ClusterSlotNode) int {
+	var candidates []int
+	for i, node := range nodes {
+		if !node.Master && !node.Loading {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return 0
+	}
+	return candidates[rand.Intn(len(candidates))]
+}
+
 type clusterNode struct {
 	Client *Client
 
@@ -138,7 +202,7 @@ func newClusterNode(clOpt *ClusterOptions, addr string) *clusterNode {
 	}
 
 	node.latency = math.MaxUint32
-	if clOpt.RouteByLatency {
+	if clOpt.RouteByLatency || clOpt.NodeSelector != nil {
 		go node.updateLatency()
 	}
 
@@ -502,6 +566,29 @@ func (c *clusterState) slotClosestNode(slot int) (*clusterNode, error) {
 	return node, nil
 }
 
+func (c *clusterState) slotSelectedNode(slot int, selector NodeSelector) (*clusterNode, error) {
+	nodes := c.slotNodes(slot)
+	if len(nodes) == 0 {
+		return c.nodes.Random()
+	}
+
+	candidates := make([]ClusterSlotNode, len(nodes))
+	for i, node := range nodes {
+		candidates[i] = ClusterSlotNode{
+			Addr:    node.Client.getAddr(),
+			Master:  i == 0,
+			Latency: node.Latency(),
+			Loading: node.Loading(),
+		}
+	}
+
+	n := selector(slot, candidates)
+	if n < 0 || n >= len(nodes) {
+		return nodes[0], nil
+	}
+	return nodes[n], nil
+}
+
 func (c *clusterState) slotRandomNode(slot int) *clusterNode {
 	nodes := c.slotNodes(slot)
 	n := rand.Intn(len(nodes))
@@ -622,6 +709,9 @@ func NewClusterClient(opt *ClusterOptions) *ClusterClient {
 	if opt.IdleCheckFrequency > 0 {
 		go c.reaper(opt.IdleCheckFrequency)
 	}
+	if opt.StateReloadFrequency > 0 {
+		go c.stateReloader(opt.StateReloadFrequency)
+	}
 
 	return c
 }
@@ -699,6 +789,11 @@ func (c *ClusterClient) cmdSlotAndNode(cmd Cmder) (int, *clusterNode, error) {
 	slot := cmdSlot(cmd, cmdFirstKeyPos(cmd, cmdInfo))
 
 	if cmdInfo != nil && cmdInfo.ReadOnly && c.opt.ReadOnly {
+		if c.opt.NodeSelector != nil {
+			node, err := state.slotSelectedNode(slot, c.opt.NodeSelector)
+			return slot, node, err
+		}
+
 		if c.opt.RouteByLatency {
 			node, err := state.slotClosestNode(slot)
 			return slot, node, err
@@ -785,6 +880,19 @@ func (c *ClusterClient) Watch(fn func(*Tx) error, keys ...string) error {
 	return err
 }
 
+// ReAuth authenticates connections to every known node again,
+// see Client.ReAuth. Nodes discovered later use CredentialsProvider
+// when connections are established.
+func (c *ClusterClient) ReAuth() {
+	nodes, err := c.nodes.All()
+	if err != nil {
+		return
+	}
+	for _, node := range nodes {
+		node.Client.ReAuth()
+	}
+}
+
 // Close closes the cluster client, releasing any open resources.
 //
 // It is rare to Close a ClusterClient, as the ClusterClient is meant
@@ -1044,6 +1152,7 @@ cluster_stats_messages_sent:1483972 通过node-to-node二进制总线发送的
 cluster_stats_messages_received:1483968 通过node-to-node二进制总线接收的消息数量.
 	*/
 
+	var views []clusterSlotsView
 	for _, addr := range addrs {
 		node, err := c.nodes.GetOrCreate(addr) // 获取或者创建一个集群节点
 		if err != nil {
@@ -1071,7 +1180,7 @@ cluster_stats_messages_received:1483968 通过node-to-node二进制总线接收
 		第二个副本
 		…直到所有的副本都打印出来
 		*/
-		slots, err := node.Client.ClusterSlots().Result()
+		slots, err := c.nodeSlots(node)
 		if err != nil {
 			if firstErr == nil {
 				firstErr = err
@@ -1079,11 +1188,130 @@ cluster_stats_messages_received:1483968 通过node-to-node二进制总线接收
 			continue
 		}
 
-		// 如果已经获得了集群信息就直接返回了
-		return newClusterState(c.nodes, slots, node.Client.opt.Addr)
+		views = append(views, clusterSlotsView{
+			origin: node.Client.opt.Addr,
+			slots:  slots,
+		})
+		// 已经从足够多的节点获得了集群信息
+		if len(views) >= c.opt.StateConsensus {
+			break
+		}
+	}
+
+	if len(views) == 0 {
+		return nil, firstErr
+	}
+
+	view := consensusView(views)
+	return newClusterState(c.nodes, view.slots, view.origin)
+}
+
+func (c *ClusterClient) nodeSlots(node *clusterNode) ([]ClusterSlot, error) {
+	if !c.opt.UseClusterNodes {
+		return node.Client.ClusterSlots().Result()
+	}
+
+	nodes, err := node.Client.ClusterNodesInfo().Result()
+	if err != nil {
+		return nil, err
+	}
+	return clusterNodesSlots(nodes, node.Client.opt.Addr), nil
+}
+
+// clusterNodesSlots converts CLUSTER NODES reply to CLUSTER SLOTS format
+// skipping slaves that are failing or disconnected.
+func clusterNodesSlots(nodes []ClusterNodeInfo, origin string) []ClusterSlot {
+	originHost, _, _ := net.SplitHostPort(origin)
+	addr := func(node *ClusterNodeInfo) string {
+		// Node that is alone in the cluster does not know its own IP.
+		host, port, err := net.SplitHostPort(node.Addr)
+		if err == nil && host == "" {
+			return net.JoinHostPort(originHost, port)
+		}
+		return node.Addr
+	}
+
+	var slots []ClusterSlot
+	for i := range nodes {
+		master := &nodes[i]
+		if !master.IsMaster() || len(master.Slots) == 0 {
+			continue
+		}
+
+		slotNodes := []ClusterNode{{Id: master.Id, Addr: addr(master)}}
+		for j := range nodes {
+			slave := &nodes[j]
+			if slave.MasterId != master.Id || !slave.IsHealthy() {
+				continue
+			}
+			slotNodes = append(slotNodes, ClusterNode{Id: slave.Id, Addr: addr(slave)})
+		}
+
+		for _, r := range master.Slots {
+			slots = append(slots, ClusterSlot{
+				Start: r.Start,
+				End:   r.End,
+				Nodes: slotNodes,
+			})
+		}
+	}
+	return slots
+}
+
+type clusterSlotsView struct {
+	origin string
+	slots  []ClusterSlot
+}
+
+// key returns a string that is equal for views describing the same
+// slots layout regardless of the order in which nodes reported it.
+func (v *clusterSlotsView) key() string {
+	ss := make([]string, len(v.slots))
+	for i, slot := range v.slots {
+		addrs := make([]string, len(slot.Nodes))
+		for j, node := range slot.Nodes {
+			addrs[j] = node.Addr
+		}
+		if len(addrs) > 1 {
+			sort.Strings(addrs[1:])
+		}
+		ss[i] = fmt.Sprintf("%d-%d:%s", slot.Start, slot.End, strings.Join(addrs, ","))
+	}
+	sort.Strings(ss)
+	return strings.Join(ss, ";")
+}
+
+// consensusView returns the view reported by the most nodes. On a tie
+// the view that was loaded first wins.
+func consensusView(views []clusterSlotsView) *clusterSlotsView {
+	if len(views) == 1 {
+		return &views[0]
+	}
+
+	counts := make(map[string]int, len(views))
+	keys := make([]string, len(views))
+	best := 0
+	for i := range views {
+		keys[i] = views[i].key()
+		counts[keys[i]]++
+		if counts[keys[i]] > counts[keys[best]] {
+			best = i
+		}
 	}
+	return &views[best]
+}
+
+// stateReloader periodically reloads cluster state in background.
+func (c *ClusterClient) stateReloader(frequency time.Duration) {
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
 
-	return nil, firstErr
+	for range ticker.C {
+		if _, err := c.nodes.All(); err != nil {
+			break
+		}
+		c.state.LazyReload()
+	}
 }
 
 // reaper closes idle connections to the cluster.
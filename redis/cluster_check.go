@@ -0,0 +1,257 @@
+package redis
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/go-redis/redis/internal/hashtag"
+)
+
+// ClusterCheck is a report about cluster consistency returned
+// by ClusterClient.Check.
+type ClusterCheck struct {
+	// Slot ranges that are not served by any master.
+	Uncovered []ClusterSlotRange
+	// Slots claimed by more than one master mapped to ids of the masters.
+	Overlapping map[int][]string
+	// Slots that are being migrated or imported.
+	OpenSlots []ClusterOpenSlot
+	// Nodes whose config epoch is reported differently by other nodes.
+	EpochConflicts []ClusterEpochConflict
+	// Addresses of nodes whose CLUSTER SLOTS reply differs from
+	// the reply of the majority of nodes.
+	InconsistentNodes []string
+	// Ids of masters that serve slots and have no healthy replicas.
+	MastersWithoutReplicas []string
+	// Errors of nodes that could not be queried keyed by node address.
+	// The rest of the report is based on the reachable nodes only.
+	UnreachableNodes map[string]error
+}
+
+// ClusterOpenSlot describes a slot in migrating or importing state.
+type ClusterOpenSlot struct {
+	Slot   int
+	NodeId string
+	// State is either "migrating" or "importing".
+	State string
+	// Id of the node the slot is migrated to or imported from.
+	PeerId string
+}
+
+// ClusterEpochConflict describes a node whose config epoch
+// is not agreed on.
+type ClusterEpochConflict struct {
+	NodeId string
+	// Config epochs keyed by address of the node that reported them.
+	Epochs map[string]int64
+}
+
+// OK reports whether the check found no problems. Masters without
+// replicas are not considered a problem.
+func (c *ClusterCheck) OK() bool {
+	return len(c.Uncovered) == 0 &&
+		len(c.Overlapping) == 0 &&
+		len(c.OpenSlots) == 0 &&
+		len(c.EpochConflicts) == 0 &&
+		len(c.InconsistentNodes) == 0 &&
+		len(c.UnreachableNodes) == 0
+}
+
+// clusterNodeView is the cluster as seen by a single node.
+type clusterNodeView struct {
+	addr  string
+	nodes []ClusterNodeInfo
+	slots []ClusterSlot
+}
+
+// Check queries CLUSTER NODES and CLUSTER SLOTS on every node and
+// verifies that nodes agree on cluster configuration, like
+// `redis-cli --cluster check` does. Nodes that can't be queried are
+// reported in ClusterCheck.UnreachableNodes.
+func (c *ClusterClient) Check() (*ClusterCheck, error) {
+	var mu sync.Mutex
+	views := make(map[string]clusterNodeView)
+	errs, err := c.forEachNodeAddr(func(addr string, client *Client) error {
+		nodes, err := client.ClusterNodesInfo().Result()
+		if err != nil {
+			return err
+		}
+		slots, err := client.ClusterSlots().Result()
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		views[addr] = clusterNodeView{
+			addr:  addr,
+			nodes: nodes,
+			slots: slots,
+		}
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort views, so ties in consensus are resolved the same way.
+	addrs := make([]string, 0, len(views))
+	for addr := range views {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	sorted := make([]clusterNodeView, len(addrs))
+	for i, addr := range addrs {
+		sorted[i] = views[addr]
+	}
+
+	check := checkCluster(sorted)
+	if len(errs) > 0 {
+		check.UnreachableNodes = errs
+	}
+	return check, nil
+}
+
+func checkCluster(views []clusterNodeView) *ClusterCheck {
+	check := &ClusterCheck{
+		Overlapping: make(map[int][]string),
+	}
+
+	owners := make([][]string, hashtag.SlotNumber)
+	epochs := make(map[string]map[string]int64)
+	replicas := make(map[string]int)
+	var masters []string
+	for _, view := range views {
+		for _, node := range view.nodes {
+			if epochs[node.Id] == nil {
+				epochs[node.Id] = make(map[string]int64)
+			}
+			epochs[node.Id][view.addr] = node.ConfigEpoch
+
+			// Slots, open slots and replication are reported
+			// by every node about itself.
+			if !node.HasFlag("myself") {
+				continue
+			}
+
+			if node.IsSlave() {
+				if node.IsHealthy() {
+					replicas[node.MasterId]++
+				}
+				continue
+			}
+
+			if len(node.Slots) > 0 {
+				masters = append(masters, node.Id)
+			}
+			for _, r := range node.Slots {
+				for slot := r.Start; slot <= r.End && slot < hashtag.SlotNumber; slot++ {
+					owners[slot] = append(owners[slot], node.Id)
+				}
+			}
+			for slot, peer := range node.Migrating {
+				check.OpenSlots = append(check.OpenSlots, ClusterOpenSlot{
+					Slot:   slot,
+					NodeId: node.Id,
+					State:  "migrating",
+					PeerId: peer,
+				})
+			}
+			for slot, peer := range node.Importing {
+				check.OpenSlots = append(check.OpenSlots, ClusterOpenSlot{
+					Slot:   slot,
+					NodeId: node.Id,
+					State:  "importing",
+					PeerId: peer,
+				})
+			}
+		}
+	}
+
+	for slot := 0; slot < hashtag.SlotNumber; slot++ {
+		ids := owners[slot]
+		if len(ids) > 1 {
+			sort.Strings(ids)
+			check.Overlapping[slot] = ids
+			continue
+		}
+		if len(ids) == 1 {
+			continue
+		}
+
+		n := len(check.Uncovered)
+		if n > 0 && check.Uncovered[n-1].End == slot-1 {
+			check.Uncovered[n-1].End = slot
+		} else {
+			check.Uncovered = append(check.Uncovered, ClusterSlotRange{
+				Start: slot,
+				End:   slot,
+			})
+		}
+	}
+	sort.Sort(clusterOpenSlots(check.OpenSlots))
+
+	ids := make([]string, 0, len(epochs))
+	for id := range epochs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if !sameEpochs(epochs[id]) {
+			check.EpochConflicts = append(check.EpochConflicts, ClusterEpochConflict{
+				NodeId: id,
+				Epochs: epochs[id],
+			})
+		}
+	}
+
+	sort.Strings(masters)
+	for _, id := range masters {
+		if replicas[id] == 0 {
+			check.MastersWithoutReplicas = append(check.MastersWithoutReplicas, id)
+		}
+	}
+
+	if len(views) > 0 {
+		slotsViews := make([]clusterSlotsView, len(views))
+		for i, view := range views {
+			slotsViews[i] = clusterSlotsView{
+				origin: view.addr,
+				slots:  view.slots,
+			}
+		}
+		consensus := consensusView(slotsViews).key()
+		for i := range slotsViews {
+			if slotsViews[i].key() != consensus {
+				check.InconsistentNodes = append(check.InconsistentNodes, slotsViews[i].origin)
+			}
+		}
+		sort.Strings(check.InconsistentNodes)
+	}
+
+	return check
+}
+
+func sameEpochs(epochs map[string]int64) bool {
+	var first int64
+	var seen bool
+	for _, epoch := range epochs {
+		if !seen {
+			first, seen = epoch, true
+		} else if epoch != first {
+			return false
+		}
+	}
+	return true
+}
+
+type clusterOpenSlots []ClusterOpenSlot
+
+func (p clusterOpenSlots) Len() int { return len(p) }
+func (p clusterOpenSlots) Less(i, j int) bool {
+	if p[i].Slot != p[j].Slot {
+		return p[i].Slot < p[j].Slot
+	}
+	return p[i].NodeId < p[j].NodeId
+}
+func (p clusterOpenSlots) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
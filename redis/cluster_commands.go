@@ -1,7 +1,126 @@
 package redis
 
-import "sync/atomic"
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
 
+// Keyless server commands are not bound to a slot, so ClusterClient
+// fans them out to a set of nodes and aggregates the replies.
+type clusterFanOut int
+
+const (
+	// fanOutMasters sends the command to every master node.
+	fanOutMasters clusterFanOut = iota
+	// fanOutNodes sends the command to every master and slave node.
+	fanOutNodes
+)
+
+func (c *ClusterClient) fanOut(policy clusterFanOut, fn func(client *Client) error) error {
+	if policy == fanOutNodes {
+		return c.ForEachNode(fn)
+	}
+	return c.ForEachMaster(fn)
+}
+
+// forEachNodeAddr is like ForEachNode, but keeps running fn on the rest
+// of nodes when some of them fail and returns the errors keyed by node
+// address. It fails only when cluster state can't be loaded.
+func (c *ClusterClient) forEachNodeAddr(fn func(addr string, client *Client) error) (map[string]error, error) {
+	state, err := c.state.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	errs := make(map[string]error)
+	worker := func(node *clusterNode) {
+		defer wg.Done()
+		addr := node.Client.getAddr()
+		if err := fn(addr, node.Client); err != nil {
+			mu.Lock()
+			errs[addr] = err
+			mu.Unlock()
+		}
+	}
+
+	for _, node := range state.masters {
+		wg.Add(1)
+		go worker(node)
+	}
+	for _, node := range state.slaves {
+		wg.Add(1)
+		go worker(node)
+	}
+
+	wg.Wait()
+	return errs, nil
+}
+
+// NodeErrors is returned by commands sent to every cluster node when
+// some of the nodes fail. It maps node address to the error of the node.
+// Replies of the rest of nodes are still returned by such commands.
+type NodeErrors map[string]error
+
+func (e NodeErrors) Error() string {
+	addrs := make([]string, 0, len(e))
+	for addr := range e {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	msgs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		msgs[i] = fmt.Sprintf("%s: %s", addr, e[addr])
+	}
+	return "redis: " + strings.Join(msgs, "; ")
+}
+
+// collectNodes runs fn on every node and returns the replies keyed by
+// node address. Failed nodes are reported with NodeErrors.
+func (c *ClusterClient) collectNodes(fn func(client *Client) (interface{}, error)) (map[string]interface{}, error) {
+	var mu sync.Mutex
+	m := make(map[string]interface{})
+	errs, err := c.forEachNodeAddr(func(addr string, client *Client) error {
+		v, err := fn(client)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		m[addr] = v
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return m, NodeErrors(errs)
+	}
+	return m, nil
+}
+
+// fanOutStatus sends the command to the nodes selected by the policy
+// and succeeds only if every node replies with success.
+func (c *ClusterClient) fanOutStatus(policy clusterFanOut, args ...interface{}) *StatusCmd {
+	cmd := NewStatusCmd(args...)
+	err := c.fanOut(policy, func(client *Client) error {
+		return client.Process(NewStatusCmd(args...))
+	})
+	if err != nil {
+		cmd.setErr(err)
+		return cmd
+	}
+	cmd.val = "OK"
+	return cmd
+}
+
+// DBSize returns the total number of keys stored on all master nodes.
 func (c *ClusterClient) DBSize() *IntCmd {
 	cmd := NewIntCmd("dbsize")
 	var size int64
@@ -20,3 +139,178 @@ func (c *ClusterClient) DBSize() *IntCmd {
 	cmd.val = size
 	return cmd
 }
+
+// FlushDB removes all keys from the current database on every master.
+func (c *ClusterClient) FlushDB() *StatusCmd {
+	return c.fanOutStatus(fanOutMasters, "flushdb")
+}
+
+// FlushDBAsync is like FlushDB, but masters free the memory in background.
+func (c *ClusterClient) FlushDBAsync() *StatusCmd {
+	return c.fanOutStatus(fanOutMasters, "flushdb", "async")
+}
+
+// FlushAll removes all keys from all databases on every master.
+func (c *ClusterClient) FlushAll() *StatusCmd {
+	return c.fanOutStatus(fanOutMasters, "flushall")
+}
+
+// FlushAllAsync is like FlushAll, but masters free the memory in background.
+func (c *ClusterClient) FlushAllAsync() *StatusCmd {
+	return c.fanOutStatus(fanOutMasters, "flushall", "async")
+}
+
+// Keys returns the union of keys matching the pattern on all masters.
+func (c *ClusterClient) Keys(pattern string) *StringSliceCmd {
+	cmd := NewStringSliceCmd("keys", pattern)
+	var mu sync.Mutex
+	var keys []string
+	err := c.ForEachMaster(func(master *Client) error {
+		vals, err := master.Keys(pattern).Result()
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		keys = append(keys, vals...)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		cmd.setErr(err)
+		return cmd
+	}
+	cmd.val = keys
+	return cmd
+}
+
+// RandomKey returns a random key from a random master. Masters without
+// keys are skipped, so Nil is returned only when the cluster is empty.
+func (c *ClusterClient) RandomKey() *StringCmd {
+	cmd := NewStringCmd("randomkey")
+
+	state, err := c.state.Get()
+	if err != nil {
+		cmd.setErr(err)
+		return cmd
+	}
+
+	cmd.setErr(Nil)
+	for _, i := range rand.Perm(len(state.masters)) {
+		key, err := state.masters[i].Client.RandomKey().Bytes()
+		if err == Nil {
+			continue
+		}
+		cmd.val, cmd.err = key, err
+		break
+	}
+	return cmd
+}
+
+// ConfigSet changes the configuration parameter on every node.
+func (c *ClusterClient) ConfigSet(parameter, value string) *StatusCmd {
+	return c.fanOutStatus(fanOutNodes, "config", "set", parameter, value)
+}
+
+// ScriptFlush flushes the Lua scripts cache on every node.
+func (c *ClusterClient) ScriptFlush() *StatusCmd {
+	return c.fanOutStatus(fanOutNodes, "script", "flush")
+}
+
+// ScriptLoad loads the script into the scripts cache of every node,
+// so it can be run with EvalSha regardless of the key slot.
+func (c *ClusterClient) ScriptLoad(script string) *StringCmd {
+	cmd := NewStringCmd("script", "load", script)
+	var mu sync.Mutex
+	err := c.ForEachNode(func(client *Client) error {
+		sha, err := client.ScriptLoad(script).Bytes()
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		if cmd.val == nil {
+			cmd.val = sha
+		}
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		cmd.val = nil
+		cmd.setErr(err)
+	}
+	return cmd
+}
+
+// NodesInfo returns INFO output of every node keyed by node address.
+// When some nodes fail, the error is NodeErrors and the map contains
+// the rest of nodes.
+func (c *ClusterClient) NodesInfo(section ...string) *StringStringMapCmd {
+	args := []interface{}{"info"}
+	if len(section) > 0 {
+		args = append(args, section[0])
+	}
+	cmd := NewStringStringMapCmd(args...)
+
+	vals, err := c.collectNodes(func(client *Client) (interface{}, error) {
+		return client.Info(section...).Result()
+	})
+	if vals != nil {
+		m := make(map[string]string, len(vals))
+		for addr, v := range vals {
+			m[addr] = v.(string)
+		}
+		cmd.val = m
+	}
+	if err != nil {
+		cmd.setErr(err)
+	}
+	return cmd
+}
+
+// InfoMap is like NodesInfo, but parses INFO output of every node.
+func (c *ClusterClient) InfoMap(section ...string) *InfoMapCmd {
+	args := []interface{}{"info"}
+	if len(section) > 0 {
+		args = append(args, section[0])
+	}
+	cmd := NewInfoMapCmd(args...)
+
+	infos, err := c.NodesInfo(section...).Result()
+	if infos != nil {
+		m := make(map[string]Info, len(infos))
+		for addr, s := range infos {
+			m[addr] = parseInfo(s)
+		}
+		cmd.val = m
+	}
+	if err != nil {
+		cmd.setErr(err)
+	}
+	return cmd
+}
+
+// SlowLogMap returns the latest num slow commands of every node
+// keyed by node address. When some nodes fail, the error is NodeErrors
+// and the map contains the rest of nodes.
+func (c *ClusterClient) SlowLogMap(num int64) *SlowLogMapCmd {
+	cmd := NewSlowLogMapCmd("slowlog", "get", num)
+
+	vals, err := c.collectNodes(func(client *Client) (interface{}, error) {
+		return client.SlowLogGet(num).Result()
+	})
+	if vals != nil {
+		m := make(map[string][]SlowLog, len(vals))
+		for addr, v := range vals {
+			m[addr] = v.([]SlowLog)
+		}
+		cmd.val = m
+	}
+	if err != nil {
+		cmd.setErr(err)
+	}
+	return cmd
+}
+
+// SlowLogReset resets slow logs of all nodes.
+func (c *ClusterClient) SlowLogReset() *StatusCmd {
+	return c.fanOutStatus(fanOutNodes, "slowlog", "reset")
+}
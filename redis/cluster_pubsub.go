@@ -0,0 +1,443 @@
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/internal"
+	"github.com/go-redis/redis/internal/hashtag"
+	"github.com/go-redis/redis/internal/pool"
+)
+
+// ShardedPubSub implements sharded Pub/Sub on Redis Cluster. Every shard
+// channel is subscribed on the master that owns the channel slot using
+// a single connection per master, and messages received from all masters
+// are merged. It's safe for concurrent use by multiple goroutines.
+//
+// Channels are automatically resubscribed on the new master when their
+// slots are moved to another node.
+type ShardedPubSub struct {
+	cluster *ClusterClient
+
+	mu sync.Mutex
+	// channels maps a channel to the address of the node it is subscribed
+	// on. Channels unsubscribed by the server have empty address.
+	channels map[string]string
+	subs     map[string]*PubSub
+	closed   bool
+	// err is a subscription error returned by the next Receive.
+	err error
+
+	replies chan shardReply
+	exit    chan struct{}
+
+	reassigning uint32 // atomic
+
+	chOnce sync.Once
+	ch     chan *Message
+}
+
+type shardReply struct {
+	msg interface{}
+	err error
+}
+
+func (c *ClusterClient) shardedPubSub() *ShardedPubSub {
+	return &ShardedPubSub{
+		cluster:  c,
+		channels: make(map[string]string),
+		subs:     make(map[string]*PubSub),
+		replies:  make(chan shardReply),
+		exit:     make(chan struct{}),
+	}
+}
+
+// SSubscribe subscribes the client to the specified shard channels.
+// Channels can be omitted to create empty subscription.
+func (c *ClusterClient) SSubscribe(channels ...string) *ShardedPubSub {
+	pubsub := c.shardedPubSub()
+	if len(channels) > 0 {
+		if err := pubsub.SSubscribe(channels...); err != nil {
+			pubsub.mu.Lock()
+			pubsub.err = err
+			pubsub.mu.Unlock()
+		}
+	}
+	return pubsub
+}
+
+// SSubscribe the client to the specified shard channels.
+func (c *ShardedPubSub) SSubscribe(channels ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return pool.ErrClosed
+	}
+
+	for _, channel := range channels {
+		c.channels[channel] = ""
+	}
+	return c._subscribe(channels)
+}
+
+// _subscribe subscribes the channels on masters owning their slots.
+func (c *ShardedPubSub) _subscribe(channels []string) error {
+	state, err := c.cluster.state.Get()
+	if err != nil {
+		return err
+	}
+
+	bySlot := channelsBySlot(channels)
+	nodes := make(map[int]*clusterNode, len(bySlot))
+	for slot := range bySlot {
+		node, err := state.slotMasterNode(slot)
+		if err != nil {
+			return err
+		}
+		nodes[slot] = node
+	}
+
+	var firstErr error
+	for slot, channels := range bySlot {
+		node := nodes[slot]
+		addr := node.Client.getAddr()
+		err := c._pubSub(node).SSubscribe(channels...)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		for _, channel := range channels {
+			c.channels[channel] = addr
+		}
+	}
+	return firstErr
+}
+
+// _pubSub returns the subscription on the node starting a receiver
+// for the node if needed.
+func (c *ShardedPubSub) _pubSub(node *clusterNode) *PubSub {
+	addr := node.Client.getAddr()
+	pubsub, ok := c.subs[addr]
+	if !ok {
+		pubsub = node.Client.pubSub()
+		c.subs[addr] = pubsub
+		go c.receive(addr, pubsub)
+	}
+	return pubsub
+}
+
+// SUnsubscribe the client from the given shard channels, or from all of
+// them if none is given.
+func (c *ShardedPubSub) SUnsubscribe(channels ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return pool.ErrClosed
+	}
+
+	if len(channels) == 0 {
+		for channel := range c.channels {
+			channels = append(channels, channel)
+		}
+	}
+
+	byAddr := make(map[string][]string)
+	for _, channel := range channels {
+		addr, ok := c.channels[channel]
+		if !ok {
+			continue
+		}
+		delete(c.channels, channel)
+		if addr != "" {
+			byAddr[addr] = append(byAddr[addr], channel)
+		}
+	}
+
+	var firstErr error
+	for addr, channels := range byAddr {
+		for _, channels := range channelsBySlot(channels) {
+			err := c.subs[addr].SUnsubscribe(channels...)
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// Ping sends PING to every node the client is subscribed on.
+func (c *ShardedPubSub) Ping(payload ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return pool.ErrClosed
+	}
+
+	var firstErr error
+	for _, pubsub := range c.subs {
+		if err := pubsub.Ping(payload...); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (c *ShardedPubSub) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return pool.ErrClosed
+	}
+	c.closed = true
+	close(c.exit)
+
+	var firstErr error
+	for addr, pubsub := range c.subs {
+		if err := pubsub.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(c.subs, addr)
+	}
+	return firstErr
+}
+
+// receive reads replies from the node and forwards them to the merged
+// receiver until the subscription is closed.
+func (c *ShardedPubSub) receive(addr string, pubsub *PubSub) {
+	var errNum uint
+	for {
+		msg, err := pubsub.Receive()
+		if err == pool.ErrClosed {
+			return
+		}
+		if err != nil {
+			if internal.IsNetworkError(err) {
+				errNum++
+				if errNum >= 3 {
+					// Node is down. Its slots can be served by
+					// a promoted slave soon.
+					c.reassign()
+					time.Sleep(time.Second)
+				}
+				continue
+			}
+
+			if moved, _, _ := internal.IsMovedError(err); moved {
+				// Channels were subscribed on a node that no longer
+				// owns their slot.
+				c.lose(addr, movedSlot(err))
+				continue
+			}
+		}
+		errNum = 0
+
+		if sub, ok := msg.(*Subscription); ok && sub.Kind == "sunsubscribe" {
+			// Server unsubscribes clients when the channel slot is moved
+			// to another node.
+			c.lose(addr, hashtag.Slot(sub.Channel))
+		}
+
+		select {
+		case c.replies <- shardReply{msg: msg, err: err}:
+		case <-c.exit:
+			return
+		}
+	}
+}
+
+// lose marks channels of the slot subscribed on the node as lost
+// and resubscribes them on the new owner of the slot.
+func (c *ShardedPubSub) lose(addr string, slot int) {
+	c.mu.Lock()
+	var lost bool
+	for channel, chAddr := range c.channels {
+		if chAddr == addr && hashtag.Slot(channel) == slot {
+			c.channels[channel] = ""
+			lost = true
+		}
+	}
+	c.mu.Unlock()
+
+	if lost {
+		c.reassign()
+	}
+}
+
+// reassign reloads cluster state in background and moves lost channels
+// and channels whose slots changed owner to the new owner.
+func (c *ShardedPubSub) reassign() {
+	if !atomic.CompareAndSwapUint32(&c.reassigning, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreUint32(&c.reassigning, 0)
+
+		// Give the cluster time to propagate the new configuration.
+		time.Sleep(100 * time.Millisecond)
+
+		state, err := c.cluster.state.Load()
+		if err != nil {
+			internal.Logf("redis: cluster state reload failed: %s", err)
+			return
+		}
+		if err := c._reassign(state); err != nil {
+			internal.Logf("redis: resubscribing shard channels failed: %s", err)
+		}
+	}()
+}
+
+func (c *ShardedPubSub) _reassign(state *clusterState) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+
+	var channels []string
+	moved := make(map[string][]string)
+	for channel, addr := range c.channels {
+		node, err := state.slotMasterNode(hashtag.Slot(channel))
+		if err != nil {
+			return err
+		}
+		if addr == node.Client.getAddr() {
+			continue
+		}
+		if addr != "" {
+			moved[addr] = append(moved[addr], channel)
+		}
+		channels = append(channels, channel)
+	}
+
+	for addr, channels := range moved {
+		for _, channels := range channelsBySlot(channels) {
+			// Old node can be unavailable, so errors are ignored.
+			_ = c.subs[addr].SUnsubscribe(channels...)
+		}
+	}
+	return c._subscribe(channels)
+}
+
+// ReceiveTimeout acts like Receive but returns an error if message
+// is not received in time.
+func (c *ShardedPubSub) ReceiveTimeout(timeout time.Duration) (interface{}, error) {
+	c.mu.Lock()
+	err := c.err
+	c.err = nil
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var timeoutCh <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
+	select {
+	case reply := <-c.replies:
+		return reply.msg, reply.err
+	case <-c.exit:
+		return nil, pool.ErrClosed
+	case <-timeoutCh:
+		return nil, errShardedPubSubTimeout
+	}
+}
+
+// Receive returns a message as a Subscription, Message, Pong or error
+// received from any of the nodes.
+func (c *ShardedPubSub) Receive() (interface{}, error) {
+	return c.ReceiveTimeout(0)
+}
+
+// ReceiveMessage returns a Message or error ignoring Subscription or Pong
+// messages.
+func (c *ShardedPubSub) ReceiveMessage() (*Message, error) {
+	for {
+		msgi, err := c.Receive()
+		if err != nil {
+			return nil, err
+		}
+
+		switch msg := msgi.(type) {
+		case *Subscription:
+			// Ignore.
+		case *Pong:
+			// Ignore.
+		case *Message:
+			return msg, nil
+		default:
+			return nil, fmt.Errorf("redis: unknown message: %T", msgi)
+		}
+	}
+}
+
+// Channel returns a Go channel for concurrently receiving messages.
+// The channel is closed with ShardedPubSub. Receive or ReceiveMessage
+// APIs can not be used after channel is created.
+func (c *ShardedPubSub) Channel() <-chan *Message {
+	c.chOnce.Do(func() {
+		c.ch = make(chan *Message, 100)
+		go func() {
+			for {
+				msg, err := c.ReceiveMessage()
+				if err != nil {
+					if err == pool.ErrClosed {
+						break
+					}
+					continue
+				}
+
+				select {
+				case c.ch <- msg:
+				case <-c.exit:
+				}
+			}
+			close(c.ch)
+		}()
+	})
+	return c.ch
+}
+
+var errShardedPubSubTimeout = shardedPubSubTimeoutError{}
+
+type shardedPubSubTimeoutError struct{}
+
+func (shardedPubSubTimeoutError) Error() string   { return "redis: sharded pubsub receive timeout" }
+func (shardedPubSubTimeoutError) Timeout() bool   { return true }
+func (shardedPubSubTimeoutError) Temporary() bool { return true }
+
+// channelsBySlot groups shard channels by their slot, because all
+// channels of a single SSUBSCRIBE or SUNSUBSCRIBE must hash to the same
+// slot.
+func channelsBySlot(channels []string) map[int][]string {
+	m := make(map[int][]string)
+	for _, channel := range channels {
+		slot := hashtag.Slot(channel)
+		m[slot] = append(m[slot], channel)
+	}
+	return m
+}
+
+// movedSlot returns the slot from MOVED error or -1.
+func movedSlot(err error) int {
+	fields := strings.Fields(err.Error())
+	if len(fields) < 2 {
+		return -1
+	}
+	slot, perr := strconv.Atoi(fields[1])
+	if perr != nil {
+		return -1
+	}
+	return slot
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/go-redis/redis"
+	"github.com/go-redis/redis/clusteradmin"
 	"github.com/go-redis/redis/internal/hashtag"
 
 	. "github.com/onsi/ginkgo"
@@ -449,6 +450,72 @@ var _ = Describe("ClusterClient", func() {
 				return nil
 			}, 30*time.Second).ShouldNot(HaveOccurred())
 		})
+
+		It("supports sharded PubSub", func() {
+			// Channels are owned by different masters.
+			channels := []string{"A", "B", "C", "D", "E", "F", "G"}
+
+			pubsub := client.SSubscribe(channels...)
+			defer pubsub.Close()
+
+			for range channels {
+				msgi, err := pubsub.ReceiveTimeout(time.Second)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(msgi.(*redis.Subscription).Kind).To(Equal("ssubscribe"))
+			}
+
+			for _, channel := range channels {
+				n, err := client.SPublish(channel, "hello "+channel).Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(n).To(Equal(int64(1)))
+			}
+
+			received := make(map[string]string)
+			for range channels {
+				msg, err := pubsub.ReceiveMessage()
+				Expect(err).NotTo(HaveOccurred())
+				received[msg.Channel] = msg.Payload
+			}
+			for _, channel := range channels {
+				Expect(received[channel]).To(Equal("hello " + channel))
+			}
+
+			Expect(pubsub.SUnsubscribe()).NotTo(HaveOccurred())
+			for range channels {
+				msgi, err := pubsub.ReceiveTimeout(time.Second)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(msgi.(*redis.Subscription).Kind).To(Equal("sunsubscribe"))
+			}
+
+			_, err := pubsub.ReceiveTimeout(100 * time.Millisecond)
+			Expect(err.(net.Error).Timeout()).To(BeTrue())
+		})
+
+		It("receives keyspace notifications from every master", func() {
+			defer client.ConfigSet("notify-keyspace-events", "")
+
+			notifications, err := client.KeyspaceNotifications(&redis.KeyspaceOptions{
+				NotifyEvents: "K$",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			defer notifications.Close()
+
+			// Keys are owned by different masters.
+			keys := []string{"A", "B", "C", "D", "E", "F", "G"}
+			for _, key := range keys {
+				Expect(client.Set(key, "value", 0).Err()).NotTo(HaveOccurred())
+			}
+
+			received := make(map[string]string)
+			for range keys {
+				var e *redis.KeyspaceEvent
+				Eventually(notifications.Channel()).Should(Receive(&e))
+				received[e.Key] = e.Event
+			}
+			for _, key := range keys {
+				Expect(received[key]).To(Equal("set"))
+			}
+		})
 	}
 
 	Describe("ClusterClient", func() {
@@ -624,6 +691,125 @@ var _ = Describe("ClusterClient", func() {
 			Expect(len(keys)).To(BeNumerically("~", nkeys, nkeys/10))
 		})
 
+		It("should RANDOMKEY on empty cluster", func() {
+			err := client.RandomKey().Err()
+			Expect(err).To(Equal(redis.Nil))
+		})
+
+		It("should KEYS on every master", func() {
+			for i := 0; i < 10; i++ {
+				err := client.Set(fmt.Sprintf("key%d", i), "value", 0).Err()
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			keys, err := client.Keys("key*").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveLen(10))
+			Expect(keys).To(ContainElement("key0"))
+			Expect(keys).To(ContainElement("key9"))
+		})
+
+		It("should FLUSHDB on every master", func() {
+			for i := 0; i < 10; i++ {
+				err := client.Set(strconv.Itoa(i), "", 0).Err()
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			err := client.FlushDB().Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = client.ForEachMaster(func(master *redis.Client) error {
+				size, err := master.DBSize().Result()
+				if err != nil {
+					return err
+				}
+				if size != 0 {
+					return fmt.Errorf("%s has %d keys", master, size)
+				}
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should SCRIPT LOAD on every node", func() {
+			sha, err := client.ScriptLoad("return 1").Result()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = client.ForEachNode(func(node *redis.Client) error {
+				exists, err := node.ScriptExists(sha).Result()
+				if err != nil {
+					return err
+				}
+				if !exists[0] {
+					return fmt.Errorf("%s does not have script %s", node, sha)
+				}
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(client.ScriptFlush().Err()).NotTo(HaveOccurred())
+		})
+
+		It("should INFO on every node", func() {
+			info, err := client.NodesInfo("server").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info).To(HaveLen(6))
+			for _, s := range info {
+				Expect(s).To(ContainSubstring("redis_version"))
+			}
+		})
+
+		It("should parse INFO on every node", func() {
+			infos, err := client.InfoMap("replication").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(infos).To(HaveLen(6))
+
+			var masters, slaves int
+			for _, info := range infos {
+				switch info.Role() {
+				case "master":
+					masters++
+				case "slave":
+					slaves++
+					Expect(info.MasterLinkStatus()).To(Equal("up"))
+				}
+			}
+			Expect(masters).To(Equal(3))
+			Expect(slaves).To(Equal(3))
+		})
+
+		It("should collect SLOWLOG from every node", func() {
+			err := client.SlowLogReset().Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			logs, err := client.SlowLogMap(10).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logs).To(HaveLen(6))
+		})
+
+		It("should check cluster consistency", func() {
+			check, err := client.Check()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(check.OK()).To(BeTrue())
+			Expect(check.MastersWithoutReplicas).To(BeEmpty())
+			Expect(check.UnreachableNodes).To(BeEmpty())
+
+			master := cluster.masters()[0]
+			err = master.ClusterSetSlotMigrating(0, cluster.nodeIds[1]).Err()
+			Expect(err).NotTo(HaveOccurred())
+			defer master.ClusterSetSlotStable(0)
+
+			check, err = client.Check()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(check.OK()).To(BeFalse())
+			Expect(check.OpenSlots).To(Equal([]redis.ClusterOpenSlot{{
+				Slot:   0,
+				NodeId: cluster.nodeIds[0],
+				State:  "migrating",
+				PeerId: cluster.nodeIds[1],
+			}}))
+		})
+
 		assertClusterClient()
 	})
 
@@ -685,6 +871,217 @@ var _ = Describe("ClusterClient", func() {
 
 		assertClusterClient()
 	})
+
+	Describe("ClusterClient with UseClusterNodes", func() {
+		BeforeEach(func() {
+			opt = redisClusterOptions()
+			opt.UseClusterNodes = true
+			opt.StateConsensus = 3
+			opt.StateReloadFrequency = 500 * time.Millisecond
+			client = cluster.clusterClient(opt)
+
+			_ = client.ForEachMaster(func(master *redis.Client) error {
+				return master.FlushDB().Err()
+			})
+		})
+
+		AfterEach(func() {
+			_ = client.ForEachMaster(func(master *redis.Client) error {
+				return master.FlushDB().Err()
+			})
+			Expect(client.Close()).NotTo(HaveOccurred())
+		})
+
+		It("should CLUSTER NODES", func() {
+			nodes, err := client.ClusterNodesInfo().Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(HaveLen(6))
+
+			var masters int
+			for _, node := range nodes {
+				Expect(node.Id).To(HaveLen(40))
+				Expect(node.LinkState).To(Equal("connected"))
+				if node.IsMaster() {
+					masters++
+					Expect(node.Slots).NotTo(BeEmpty())
+				}
+			}
+			Expect(masters).To(Equal(3))
+		})
+
+		It("loads slots from CLUSTER NODES", func() {
+			Expect(client.SlotAddrs(0)).To(Equal([]string{"127.0.0.1:8220", "127.0.0.1:8223"}))
+			Expect(client.SlotAddrs(16383)).To(Equal([]string{"127.0.0.1:8222", "127.0.0.1:8225"}))
+		})
+
+		assertClusterClient()
+	})
+
+	Describe("ClusterClient with NodeSelector", func() {
+		var mu sync.Mutex
+		var selected []redis.ClusterSlotNode
+
+		BeforeEach(func() {
+			selected = nil
+
+			opt = redisClusterOptions()
+			opt.NodeSelector = func(slot int, nodes []redis.ClusterSlotNode) int {
+				n := redis.PreferSlaveSelector(slot, nodes)
+				mu.Lock()
+				selected = append(selected, nodes[n])
+				mu.Unlock()
+				return n
+			}
+			client = cluster.clusterClient(opt)
+
+			_ = client.ForEachMaster(func(master *redis.Client) error {
+				return master.FlushDB().Err()
+			})
+		})
+
+		AfterEach(func() {
+			_ = client.ForEachMaster(func(master *redis.Client) error {
+				return master.FlushDB().Err()
+			})
+			Expect(client.Close()).NotTo(HaveOccurred())
+		})
+
+		It("routes read-only commands to the selected node", func() {
+			err := client.Get("A").Err()
+			Expect(err).To(Equal(redis.Nil))
+
+			mu.Lock()
+			defer mu.Unlock()
+			Expect(selected).To(HaveLen(1))
+			Expect(selected[0].Master).To(BeFalse())
+
+			addrs := client.SlotAddrs(hashtag.Slot("A"))
+			Expect(addrs).To(HaveLen(2))
+			Expect(selected[0].Addr).To(Equal(addrs[1]))
+		})
+
+		It("does not use NodeSelector for write commands", func() {
+			err := client.Set("A", "hello", 0).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			mu.Lock()
+			defer mu.Unlock()
+			Expect(selected).To(BeEmpty())
+		})
+
+		assertClusterClient()
+	})
+})
+
+var _ = Describe("CLUSTER NODES parsing", func() {
+	const reply = `07c37dfeb235213a872192d90877d0cd55635b91 127.0.0.1:30004@31004 slave e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 0 1426238317239 4 connected
+67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1 127.0.0.1:30002@31002 master - 0 1426238316232 2 connected 5461-10922
+292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 127.0.0.1:30003@31003 master - 0 1426238318243 3 connected 10923-16383
+6ec23923021cf3ffec47632106199cb7f496ce01 127.0.0.1:30005@31005 slave,fail 67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1 0 1426238316232 5 disconnected
+824fe116063bc5fcf9f4ffd895bc17aee7731ac3 127.0.0.1:30006@31006 slave 292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 0 1426238317741 6 connected
+e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 127.0.0.1:30001@31001 myself,master - 0 0 1 connected 0-5459 5460 [5460->-67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1]
+`
+
+	It("parses nodes", func() {
+		nodes, err := redis.ParseClusterNodes(reply)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(6))
+
+		myself := nodes[5]
+		Expect(myself.Id).To(Equal("e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca"))
+		Expect(myself.Addr).To(Equal("127.0.0.1:30001"))
+		Expect(myself.Flags).To(Equal([]string{"myself", "master"}))
+		Expect(myself.MasterId).To(Equal(""))
+		Expect(myself.ConfigEpoch).To(Equal(int64(1)))
+		Expect(myself.Slots).To(Equal([]redis.ClusterSlotRange{
+			{Start: 0, End: 5459},
+			{Start: 5460, End: 5460},
+		}))
+		Expect(myself.Migrating).To(Equal(map[int]string{
+			5460: "67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1",
+		}))
+		Expect(myself.IsHealthy()).To(BeTrue())
+
+		failed := nodes[3]
+		Expect(failed.IsSlave()).To(BeTrue())
+		Expect(failed.MasterId).To(Equal("67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1"))
+		Expect(failed.IsHealthy()).To(BeFalse())
+	})
+
+	It("excludes failing slaves from slots", func() {
+		nodes, err := redis.ParseClusterNodes(reply)
+		Expect(err).NotTo(HaveOccurred())
+
+		slots := redis.ClusterNodesSlots(nodes, "127.0.0.1:30001")
+		Expect(slots).To(HaveLen(4))
+		for _, slot := range slots {
+			switch slot.Start {
+			case 5461:
+				Expect(slot.Nodes).To(HaveLen(1))
+			default:
+				Expect(slot.Nodes).To(HaveLen(2))
+			}
+		}
+	})
+
+	It("returns an error on malformed line", func() {
+		_, err := redis.ParseClusterNodes("foo bar")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("ClusterCheck", func() {
+	It("reports healthy cluster", func() {
+		check, err := redis.CheckClusterNodes(
+			`aaa 127.0.0.1:7000@17000 myself,master - 0 0 1 connected 0-8191
+bbb 127.0.0.1:7001@17001 master - 0 0 2 connected 8192-16383
+ccc 127.0.0.1:7002@17002 slave aaa 0 0 1 connected`,
+			`aaa 127.0.0.1:7000@17000 master - 0 0 1 connected 0-8191
+bbb 127.0.0.1:7001@17001 myself,master - 0 0 2 connected 8192-16383
+ccc 127.0.0.1:7002@17002 slave aaa 0 0 1 connected`,
+			`aaa 127.0.0.1:7000@17000 master - 0 0 1 connected 0-8191
+bbb 127.0.0.1:7001@17001 master - 0 0 2 connected 8192-16383
+ccc 127.0.0.1:7002@17002 myself,slave aaa 0 0 1 connected`,
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(check.OK()).To(BeTrue())
+		Expect(check.MastersWithoutReplicas).To(Equal([]string{"bbb"}))
+	})
+
+	It("reports problems", func() {
+		check, err := redis.CheckClusterNodes(
+			`aaa 127.0.0.1:7000@17000 myself,master - 0 0 1 connected 0-8191 [100->-bbb]
+bbb 127.0.0.1:7001@17001 master - 0 0 2 connected 8192-16383
+ccc 127.0.0.1:7002@17002 slave aaa 0 0 1 connected`,
+			`aaa 127.0.0.1:7000@17000 master - 0 0 1 connected 0-8191
+bbb 127.0.0.1:7001@17001 myself,master - 0 0 3 connected 8000-16000 [100-<-aaa]
+ccc 127.0.0.1:7002@17002 slave aaa 0 0 1 connected`,
+			`aaa 127.0.0.1:7000@17000 master - 0 0 1 connected 0-8191
+bbb 127.0.0.1:7001@17001 master - 0 0 2 connected 8192-16383
+ccc 127.0.0.1:7002@17002 myself,slave aaa 0 0 1 connected`,
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(check.OK()).To(BeFalse())
+
+		Expect(check.Uncovered).To(Equal([]redis.ClusterSlotRange{
+			{Start: 16001, End: 16383},
+		}))
+		Expect(check.Overlapping).To(HaveLen(192))
+		Expect(check.Overlapping[8000]).To(Equal([]string{"aaa", "bbb"}))
+		Expect(check.OpenSlots).To(Equal([]redis.ClusterOpenSlot{
+			{Slot: 100, NodeId: "aaa", State: "migrating", PeerId: "bbb"},
+			{Slot: 100, NodeId: "bbb", State: "importing", PeerId: "aaa"},
+		}))
+		Expect(check.EpochConflicts).To(Equal([]redis.ClusterEpochConflict{{
+			NodeId: "bbb",
+			Epochs: map[string]int64{
+				"127.0.0.1:7000": 2,
+				"127.0.0.1:7001": 3,
+				"127.0.0.1:7002": 2,
+			},
+		}}))
+		Expect(check.InconsistentNodes).To(Equal([]string{"127.0.0.1:7001"}))
+	})
 })
 
 var _ = Describe("ClusterClient without nodes", func() {
@@ -813,6 +1210,219 @@ var _ = Describe("ClusterClient timeout", func() {
 	})
 })
 
+var _ = Describe("clusteradmin", func() {
+	var ports = []string{"8230", "8231", "8232", "8233"}
+
+	var processes []*redisProcess
+	var addrs []string
+	var admin *clusteradmin.Admin
+
+	BeforeEach(func() {
+		processes = nil
+		addrs = nil
+		for _, port := range ports {
+			process, err := startRedis(port, "--cluster-enabled", "yes")
+			Expect(err).NotTo(HaveOccurred())
+			processes = append(processes, process)
+			addrs = append(addrs, net.JoinHostPort("127.0.0.1", port))
+		}
+
+		admin = clusteradmin.New(&clusteradmin.Options{})
+	})
+
+	AfterEach(func() {
+		Expect(admin.Close()).NotTo(HaveOccurred())
+		for _, process := range processes {
+			Expect(process.Close()).NotTo(HaveOccurred())
+		}
+	})
+
+	slotsOf := func(id string) int {
+		nodes, err := admin.Nodes(addrs[0])
+		Expect(err).NotTo(HaveOccurred())
+		var n int
+		for _, node := range nodes {
+			if node.Id == id {
+				for _, r := range node.Slots {
+					n += r.End - r.Start + 1
+				}
+			}
+		}
+		return n
+	}
+
+	It("creates cluster with replicas", func() {
+		err := admin.Create(addrs, 1)
+		Expect(err).NotTo(HaveOccurred())
+
+		nodes, err := admin.Nodes(addrs[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(4))
+
+		var masters, slaves int
+		for _, node := range nodes {
+			if node.IsMaster() {
+				masters++
+				Expect(slotsOf(node.Id)).To(Equal(8192))
+			} else {
+				slaves++
+			}
+		}
+		Expect(masters).To(Equal(2))
+		Expect(slaves).To(Equal(2))
+
+		err = admin.Create(addrs, 0)
+		Expect(err).To(MatchError("clusteradmin: node 127.0.0.1:8230 is already part of a cluster"))
+	})
+
+	It("resubscribes shard channels when slots move", func() {
+		err := admin.Create(addrs[:2], 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		client := redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs: addrs[:2],
+		})
+		defer client.Close()
+
+		pubsub := client.SSubscribe("mychannel")
+		defer pubsub.Close()
+
+		msgi, err := pubsub.ReceiveTimeout(time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(msgi.(*redis.Subscription).Kind).To(Equal("ssubscribe"))
+
+		slot := hashtag.Slot("mychannel")
+		owner := client.SlotAddrs(slot)[0]
+		target := addrs[0]
+		if owner == target {
+			target = addrs[1]
+		}
+		myself, err := admin.Myself(target)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = admin.MigrateSlots(addrs[0], []int{slot}, myself.Id, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func() error {
+			err := client.SPublish("mychannel", "hello").Err()
+			if err != nil {
+				return err
+			}
+
+			for {
+				msgi, err := pubsub.ReceiveTimeout(time.Second)
+				if err != nil {
+					return err
+				}
+				// Skip sunsubscribe and ssubscribe sent during migration.
+				if msg, ok := msgi.(*redis.Message); ok {
+					Expect(msg.Payload).To(Equal("hello"))
+					return nil
+				}
+			}
+		}, 10*time.Second).ShouldNot(HaveOccurred())
+	})
+
+	It("migrates slot between password-protected nodes", func() {
+		err := admin.Create(addrs[:2], 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, process := range processes[:2] {
+			err := process.ConfigSet("requirepass", "secret").Err()
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		authAdmin := clusteradmin.New(&clusteradmin.Options{
+			Password: "secret",
+		})
+		defer authAdmin.Close()
+
+		client := redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:    addrs[:2],
+			Password: "secret",
+		})
+		defer client.Close()
+
+		Expect(client.Set("key", "hello", 0).Err()).NotTo(HaveOccurred())
+
+		slot := hashtag.Slot("key")
+		owner := client.SlotAddrs(slot)[0]
+		target := addrs[0]
+		if owner == target {
+			target = addrs[1]
+		}
+		myself, err := authAdmin.Myself(target)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = authAdmin.MigrateSlots(addrs[0], []int{slot}, myself.Id, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(authAdmin.Client(target).Get("key").Val()).To(Equal("hello"))
+	})
+
+	It("adds node, rebalances and removes node", func() {
+		err := admin.Create(addrs[:3], 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		client := redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs: addrs[:3],
+		})
+		defer client.Close()
+
+		for i := 0; i < 100; i++ {
+			key := fmt.Sprintf("key%d", i)
+			Expect(client.Set(key, key, 0).Err()).NotTo(HaveOccurred())
+		}
+
+		id, err := admin.AddNode(addrs[0], addrs[3])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(HaveLen(40))
+
+		var keys int
+		var done int
+		err = admin.Rebalance(addrs[0], &clusteradmin.MigrateOptions{
+			Pipeline: 3,
+			Progress: func(p clusteradmin.Progress) {
+				Expect(p.Target).To(Equal(id))
+				if p.Done {
+					keys += p.Keys
+					done = p.SlotsDone
+				}
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(done).To(Equal(4096))
+		Expect(keys).To(BeNumerically(">", 0))
+		Expect(slotsOf(id)).To(Equal(4096))
+		Expect(admin.Client(addrs[3]).DBSize().Val()).To(Equal(int64(keys)))
+
+		for i := 0; i < 100; i++ {
+			key := fmt.Sprintf("key%d", i)
+			Expect(client.Get(key).Val()).To(Equal(key))
+		}
+
+		err = admin.RemoveNode(addrs[0], id)
+		Expect(err).To(MatchError("clusteradmin: node " + id + " is not empty"))
+
+		myself, err := admin.Myself(addrs[0])
+		Expect(err).NotTo(HaveOccurred())
+		err = admin.Reshard(addrs[0], id, myself.Id, 4096, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(slotsOf(id)).To(Equal(0))
+
+		err = admin.RemoveNode(addrs[0], id)
+		Expect(err).NotTo(HaveOccurred())
+
+		nodes, err := admin.Nodes(addrs[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(3))
+
+		for i := 0; i < 100; i++ {
+			key := fmt.Sprintf("key%d", i)
+			Expect(client.Get(key).Val()).To(Equal(key))
+		}
+	})
+})
+
 //------------------------------------------------------------------------------
 
 func BenchmarkRedisClusterPing(b *testing.B) {
@@ -878,3 +1488,23 @@ func BenchmarkRedisClusterSetString(b *testing.B) {
 		}
 	})
 }
+
+var _ = Describe("PreferSlaveSelector", func() {
+	It("prefers slaves that are not loading", func() {
+		nodes := []redis.ClusterSlotNode{
+			{Addr: "127.0.0.1:8220", Master: true},
+			{Addr: "127.0.0.1:8223", Loading: true},
+			{Addr: "127.0.0.1:8226"},
+		}
+		Expect(redis.PreferSlaveSelector(0, nodes)).To(Equal(2))
+	})
+
+	It("falls back to master", func() {
+		nodes := []redis.ClusterSlotNode{
+			{Addr: "127.0.0.1:8220", Master: true},
+			{Addr: "127.0.0.1:8223", Loading: true},
+		}
+		Expect(redis.PreferSlaveSelector(0, nodes)).To(Equal(0))
+		Expect(redis.PreferSlaveSelector(0, nodes[:1])).To(Equal(0))
+	})
+})
@@ -0,0 +1,384 @@
+// Package clusteradmin manages Redis Cluster topology: it creates a cluster
+// from fresh nodes, adds and removes nodes, migrates slots key by key and
+// rebalances slot ownership between masters.
+//
+// It talks to every node directly using redis.Client, the same way
+// `redis-cli --cluster` does.
+package clusteradmin
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// SlotsNumber is the number of hash slots in Redis Cluster.
+const SlotsNumber = 16384
+
+// Options are used to configure an Admin and should be passed to New.
+type Options struct {
+	// Maximum time to wait until nodes agree on the cluster configuration
+	// after a topology change.
+	// Default is 30 seconds.
+	WaitTimeout time.Duration
+
+	// Following options are copied from redis.Options struct.
+
+	Username string
+	Password string
+
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+}
+
+func (opt *Options) init() {
+	if opt.WaitTimeout == 0 {
+		opt.WaitTimeout = 30 * time.Second
+	}
+}
+
+func (opt *Options) clientOptions(addr string) *redis.Options {
+	return &redis.Options{
+		Addr:     addr,
+		Username: opt.Username,
+		Password: opt.Password,
+
+		DialTimeout:  opt.DialTimeout,
+		ReadTimeout:  opt.ReadTimeout,
+		WriteTimeout: opt.WriteTimeout,
+	}
+}
+
+// Admin performs administrative operations on Redis Cluster nodes.
+// It is safe for concurrent use by multiple goroutines.
+type Admin struct {
+	opt *Options
+
+	mu      sync.Mutex
+	clients map[string]*redis.Client
+}
+
+// New returns an Admin that connects to cluster nodes on demand.
+func New(opt *Options) *Admin {
+	opt.init()
+	return &Admin{
+		opt:     opt,
+		clients: make(map[string]*redis.Client),
+	}
+}
+
+// Close closes connections to all nodes.
+func (a *Admin) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var firstErr error
+	for addr, client := range a.clients {
+		if err := client.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(a.clients, addr)
+	}
+	return firstErr
+}
+
+// Client returns a client connected to the node with the address.
+func (a *Admin) Client(addr string) *redis.Client {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	client, ok := a.clients[addr]
+	if !ok {
+		client = redis.NewClient(a.opt.clientOptions(addr))
+		a.clients[addr] = client
+	}
+	return client
+}
+
+// Nodes returns cluster nodes as seen by the node with the address.
+// Nodes that do not know their own IP are reported with the host of addr.
+func (a *Admin) Nodes(addr string) ([]redis.ClusterNodeInfo, error) {
+	nodes, err := a.Client(addr).ClusterNodesInfo().Result()
+	if err != nil {
+		return nil, err
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	for i := range nodes {
+		if strings.HasPrefix(nodes[i].Addr, ":") {
+			nodes[i].Addr = host + nodes[i].Addr
+		}
+	}
+	return nodes, nil
+}
+
+// Myself returns the node with the address as seen by itself.
+func (a *Admin) Myself(addr string) (*redis.ClusterNodeInfo, error) {
+	nodes, err := a.Nodes(addr)
+	if err != nil {
+		return nil, err
+	}
+	for i := range nodes {
+		if nodes[i].HasFlag("myself") {
+			return &nodes[i], nil
+		}
+	}
+	return nil, fmt.Errorf("clusteradmin: node %s does not report itself", addr)
+}
+
+// Create forms a cluster from fresh nodes. The first len(addrs)/(replicas+1)
+// nodes become masters with evenly split slots and the rest are assigned
+// to masters as replicas in round-robin order.
+func (a *Admin) Create(addrs []string, replicas int) error {
+	numMasters := len(addrs) / (replicas + 1)
+	if numMasters == 0 {
+		return fmt.Errorf("clusteradmin: %d nodes are not enough for %d replicas per master",
+			len(addrs), replicas)
+	}
+
+	ids := make([]string, len(addrs))
+	for i, addr := range addrs {
+		if err := a.checkEmpty(addr); err != nil {
+			return err
+		}
+		myself, err := a.Myself(addr)
+		if err != nil {
+			return err
+		}
+		ids[i] = myself.Id
+	}
+
+	for i, addr := range addrs[:numMasters] {
+		min := i * SlotsNumber / numMasters
+		max := (i+1)*SlotsNumber/numMasters - 1
+		err := a.Client(addr).ClusterAddSlotsRange(min, max).Err()
+		if err != nil {
+			return err
+		}
+	}
+
+	host, port, err := net.SplitHostPort(addrs[0])
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs[1:] {
+		err := a.Client(addr).ClusterMeet(host, port).Err()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = a.waitFor(func() error {
+		for _, addr := range addrs {
+			if err := a.knowsNodes(addr, ids); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, addr := range addrs[numMasters:] {
+		masterID := ids[i%numMasters]
+		err := a.Client(addr).ClusterReplicate(masterID).Err()
+		if err != nil {
+			return err
+		}
+	}
+
+	return a.waitFor(func() error {
+		for _, addr := range addrs {
+			if err := a.checkState(addr); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AddNode joins a fresh node to the cluster that contains the seed node
+// and returns the id of the new node. The node joins as a master
+// without slots.
+func (a *Admin) AddNode(seed, addr string) (string, error) {
+	if err := a.checkEmpty(addr); err != nil {
+		return "", err
+	}
+
+	myself, err := a.Myself(addr)
+	if err != nil {
+		return "", err
+	}
+
+	host, port, err := net.SplitHostPort(seed)
+	if err != nil {
+		return "", err
+	}
+	err = a.Client(addr).ClusterMeet(host, port).Err()
+	if err != nil {
+		return "", err
+	}
+
+	// Slots can be moved to the node only after every node knows it.
+	err = a.waitFor(func() error {
+		nodes, err := a.Nodes(seed)
+		if err != nil {
+			return err
+		}
+		for _, node := range nodes {
+			if node.HasFlag("fail") || node.HasFlag("noaddr") {
+				continue
+			}
+			if err := a.knowsNodes(node.Addr, []string{myself.Id}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return myself.Id, nil
+}
+
+// AddReplica joins a fresh node to the cluster that contains the seed node
+// and makes it a replica of the master.
+func (a *Admin) AddReplica(seed, addr, masterID string) (string, error) {
+	id, err := a.AddNode(seed, addr)
+	if err != nil {
+		return "", err
+	}
+
+	err = a.waitFor(func() error {
+		return a.knowsNodes(addr, []string{masterID})
+	})
+	if err != nil {
+		return "", err
+	}
+
+	err = a.Client(addr).ClusterReplicate(masterID).Err()
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// RemoveNode makes all other nodes forget the node and resets it.
+// A master must not serve any slots or have replicas.
+func (a *Admin) RemoveNode(seed, nodeID string) error {
+	nodes, err := a.Nodes(seed)
+	if err != nil {
+		return err
+	}
+
+	var removed *redis.ClusterNodeInfo
+	for i := range nodes {
+		node := &nodes[i]
+		if node.Id == nodeID {
+			removed = node
+			continue
+		}
+		if node.MasterId == nodeID {
+			return fmt.Errorf("clusteradmin: node %s has replica %s", nodeID, node.Id)
+		}
+	}
+	if removed == nil {
+		return fmt.Errorf("clusteradmin: node %s is not found", nodeID)
+	}
+	if len(removed.Slots) > 0 {
+		return fmt.Errorf("clusteradmin: node %s is not empty", nodeID)
+	}
+
+	for _, node := range nodes {
+		// Failed nodes can't be reached and forget nothing.
+		if node.Id == nodeID || node.HasFlag("fail") || node.HasFlag("noaddr") {
+			continue
+		}
+		err := a.Client(node.Addr).ClusterForget(nodeID).Err()
+		if err != nil {
+			return err
+		}
+	}
+
+	return a.Client(removed.Addr).ClusterResetSoft().Err()
+}
+
+// checkEmpty returns an error if the node knows other nodes, serves slots
+// or stores keys.
+func (a *Admin) checkEmpty(addr string) error {
+	nodes, err := a.Nodes(addr)
+	if err != nil {
+		return err
+	}
+	if len(nodes) != 1 || len(nodes[0].Slots) > 0 {
+		return fmt.Errorf("clusteradmin: node %s is already part of a cluster", addr)
+	}
+
+	n, err := a.Client(addr).DBSize().Result()
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf("clusteradmin: node %s is not empty", addr)
+	}
+	return nil
+}
+
+// knowsNodes returns an error until the node completes the handshake
+// with all the nodes.
+func (a *Admin) knowsNodes(addr string, ids []string) error {
+	nodes, err := a.Nodes(addr)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		if !node.HasFlag("handshake") {
+			known[node.Id] = true
+		}
+	}
+	for _, id := range ids {
+		if !known[id] {
+			return fmt.Errorf("clusteradmin: node %s does not know node %s yet", addr, id)
+		}
+	}
+	return nil
+}
+
+// checkState returns an error until the node reports cluster_state:ok.
+func (a *Admin) checkState(addr string) error {
+	info, err := a.Client(addr).ClusterInfo().Result()
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(info, "cluster_state:ok") {
+		return fmt.Errorf("clusteradmin: cluster state of node %s is not ok", addr)
+	}
+	return nil
+}
+
+func (a *Admin) waitFor(fn func() error) error {
+	const pause = 100 * time.Millisecond
+
+	deadline := time.Now().Add(a.opt.WaitTimeout)
+	for {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(pause)
+	}
+}
@@ -0,0 +1,343 @@
+package clusteradmin
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// MigrateOptions configure how slots are moved between masters.
+type MigrateOptions struct {
+	// Number of keys fetched with CLUSTER GETKEYSINSLOT and moved
+	// with a single MIGRATE.
+	// Default is 10.
+	Pipeline int
+	// Timeout of a single MIGRATE.
+	// Default is 60 seconds.
+	Timeout time.Duration
+
+	// Progress is called after every MIGRATE and once the slot
+	// is assigned to the target node.
+	Progress func(Progress)
+}
+
+func (opt *MigrateOptions) init() {
+	if opt.Pipeline <= 0 {
+		opt.Pipeline = 10
+	}
+	if opt.Timeout == 0 {
+		opt.Timeout = 60 * time.Second
+	}
+}
+
+func (opt *MigrateOptions) progress(p Progress) {
+	if opt.Progress != nil {
+		opt.Progress(p)
+	}
+}
+
+// Progress reports the state of a slot migration.
+type Progress struct {
+	Slot   int
+	Source string // source node id
+	Target string // target node id
+
+	// Number of keys of the slot moved so far.
+	Keys int
+	// Done is true once the slot is assigned to the target node.
+	Done bool
+
+	// Number of slots already moved and the number of slots to move
+	// by the operation.
+	SlotsDone  int
+	SlotsTotal int
+}
+
+type slotMove struct {
+	slot   int
+	source string
+	target string
+}
+
+// MigrateSlots moves the slots with their keys from their current owners
+// to the target master. Slots already served by the target are skipped.
+func (a *Admin) MigrateSlots(seed string, slots []int, targetID string, opt *MigrateOptions) error {
+	nodes, err := a.Nodes(seed)
+	if err != nil {
+		return err
+	}
+
+	owners := slotOwners(nodes)
+	moves := make([]slotMove, 0, len(slots))
+	for _, slot := range slots {
+		if slot < 0 || slot >= SlotsNumber {
+			return fmt.Errorf("clusteradmin: invalid slot %d", slot)
+		}
+		source := owners[slot]
+		if source == "" {
+			return fmt.Errorf("clusteradmin: slot %d is not served by any node", slot)
+		}
+		if source == targetID {
+			continue
+		}
+		moves = append(moves, slotMove{
+			slot:   slot,
+			source: source,
+			target: targetID,
+		})
+	}
+	return a.migrate(nodes, moves, opt)
+}
+
+// Reshard moves n slots with their keys from the source master
+// to the target master.
+func (a *Admin) Reshard(seed, sourceID, targetID string, n int, opt *MigrateOptions) error {
+	nodes, err := a.Nodes(seed)
+	if err != nil {
+		return err
+	}
+
+	source := findNode(nodes, sourceID)
+	if source == nil {
+		return fmt.Errorf("clusteradmin: node %s is not found", sourceID)
+	}
+
+	slots := nodeSlots(source)
+	if n > len(slots) {
+		return fmt.Errorf("clusteradmin: node %s serves only %d slots", sourceID, len(slots))
+	}
+
+	moves := make([]slotMove, n)
+	for i, slot := range slots[len(slots)-n:] {
+		moves[i] = slotMove{
+			slot:   slot,
+			source: sourceID,
+			target: targetID,
+		}
+	}
+	return a.migrate(nodes, moves, opt)
+}
+
+// Rebalance moves slots between masters so every master serves the same
+// number of slots. Masters without slots, e.g. just added with AddNode,
+// receive their share.
+func (a *Admin) Rebalance(seed string, opt *MigrateOptions) error {
+	nodes, err := a.Nodes(seed)
+	if err != nil {
+		return err
+	}
+	return a.migrate(nodes, planRebalance(nodes), opt)
+}
+
+func (a *Admin) migrate(nodes []redis.ClusterNodeInfo, moves []slotMove, opt *MigrateOptions) error {
+	if opt == nil {
+		opt = new(MigrateOptions)
+	}
+	opt.init()
+
+	for i, move := range moves {
+		p := Progress{
+			Slot:       move.slot,
+			Source:     move.source,
+			Target:     move.target,
+			SlotsDone:  i,
+			SlotsTotal: len(moves),
+		}
+		if err := a.migrateSlot(nodes, p, opt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *Admin) migrateSlot(nodes []redis.ClusterNodeInfo, p Progress, opt *MigrateOptions) error {
+	source := findNode(nodes, p.Source)
+	if source == nil {
+		return fmt.Errorf("clusteradmin: node %s is not found", p.Source)
+	}
+	target := findNode(nodes, p.Target)
+	if target == nil {
+		return fmt.Errorf("clusteradmin: node %s is not found", p.Target)
+	}
+	if !target.IsMaster() {
+		return fmt.Errorf("clusteradmin: node %s is not a master", p.Target)
+	}
+
+	err := a.migrateSlotKeys(source, target, &p, opt)
+	if err == nil {
+		// Assign the slot to target first, so it stops replying with ASK
+		// redirects back to source, and then inform the rest of masters.
+		err = a.Client(target.Addr).ClusterSetSlotNode(p.Slot, target.Id).Err()
+	}
+	if err != nil {
+		// Close the slot, so source keeps serving it. Keys already
+		// moved stay on target until the slot is migrated again.
+		a.Client(target.Addr).ClusterSetSlotStable(p.Slot)
+		a.Client(source.Addr).ClusterSetSlotStable(p.Slot)
+		return err
+	}
+
+	owners := []*redis.ClusterNodeInfo{source}
+	for i := range nodes {
+		node := &nodes[i]
+		if node.Id != source.Id && node.Id != target.Id && isActiveMaster(node) {
+			owners = append(owners, node)
+		}
+	}
+	for _, node := range owners {
+		err := a.Client(node.Addr).ClusterSetSlotNode(p.Slot, target.Id).Err()
+		if err != nil {
+			return err
+		}
+	}
+
+	p.Done = true
+	p.SlotsDone++
+	opt.progress(p)
+	return nil
+}
+
+// migrateSlotKeys opens the slot on source and target and moves
+// all keys of the slot to target.
+func (a *Admin) migrateSlotKeys(source, target *redis.ClusterNodeInfo, p *Progress, opt *MigrateOptions) error {
+	sourceClient := a.Client(source.Addr)
+	targetClient := a.Client(target.Addr)
+
+	// Target must import the slot before source starts redirecting
+	// clients with ASK.
+	err := targetClient.ClusterSetSlotImporting(p.Slot, source.Id).Err()
+	if err != nil {
+		return err
+	}
+	err = sourceClient.ClusterSetSlotMigrating(p.Slot, target.Id).Err()
+	if err != nil {
+		return err
+	}
+
+	host, port, err := net.SplitHostPort(target.Addr)
+	if err != nil {
+		return err
+	}
+	// Keys left on target by a failed migration of the slot are replaced.
+	args := redis.MigrateArgs{
+		Replace:  true,
+		Username: a.opt.Username,
+		Password: a.opt.Password,
+	}
+	for {
+		keys, err := sourceClient.ClusterGetKeysInSlot(p.Slot, opt.Pipeline).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+
+		err = sourceClient.MigrateKeys(host, port, keys, 0, opt.Timeout, args).Err()
+		if err != nil {
+			return err
+		}
+
+		p.Keys += len(keys)
+		opt.progress(*p)
+	}
+}
+
+// planRebalance returns slot moves that give every active master
+// the same number of slots. Donors give away their highest slots.
+func planRebalance(nodes []redis.ClusterNodeInfo) []slotMove {
+	var masters []*redis.ClusterNodeInfo
+	var total int
+	for i := range nodes {
+		node := &nodes[i]
+		if isActiveMaster(node) {
+			masters = append(masters, node)
+			total += len(nodeSlots(node))
+		}
+	}
+	if len(masters) == 0 {
+		return nil
+	}
+	sort.Sort(nodesByID(masters))
+
+	expected := func(i int) int {
+		n := total / len(masters)
+		if i < total%len(masters) {
+			n++
+		}
+		return n
+	}
+
+	var moves []slotMove
+	for i, master := range masters {
+		slots := nodeSlots(master)
+		if excess := len(slots) - expected(i); excess > 0 {
+			for _, slot := range slots[len(slots)-excess:] {
+				moves = append(moves, slotMove{
+					slot:   slot,
+					source: master.Id,
+				})
+			}
+		}
+	}
+
+	var next int
+	for i, master := range masters {
+		need := expected(i) - len(nodeSlots(master))
+		for ; need > 0 && next < len(moves); need-- {
+			moves[next].target = master.Id
+			next++
+		}
+	}
+	return moves[:next]
+}
+
+type nodesByID []*redis.ClusterNodeInfo
+
+func (p nodesByID) Len() int           { return len(p) }
+func (p nodesByID) Less(i, j int) bool { return p[i].Id < p[j].Id }
+func (p nodesByID) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+func isActiveMaster(node *redis.ClusterNodeInfo) bool {
+	return node.IsMaster() && !node.HasFlag("fail") && !node.HasFlag("noaddr")
+}
+
+func findNode(nodes []redis.ClusterNodeInfo, id string) *redis.ClusterNodeInfo {
+	for i := range nodes {
+		if nodes[i].Id == id {
+			return &nodes[i]
+		}
+	}
+	return nil
+}
+
+// nodeSlots returns the slots served by the node in ascending order.
+func nodeSlots(node *redis.ClusterNodeInfo) []int {
+	var slots []int
+	for _, r := range node.Slots {
+		for slot := r.Start; slot <= r.End; slot++ {
+			slots = append(slots, slot)
+		}
+	}
+	sort.Ints(slots)
+	return slots
+}
+
+func slotOwners(nodes []redis.ClusterNodeInfo) []string {
+	owners := make([]string, SlotsNumber)
+	for i := range nodes {
+		node := &nodes[i]
+		if !node.IsMaster() {
+			continue
+		}
+		for _, r := range node.Slots {
+			for slot := r.Start; slot <= r.End && slot < SlotsNumber; slot++ {
+				owners[slot] = node.Id
+			}
+		}
+	}
+	return owners
+}
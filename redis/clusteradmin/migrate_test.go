@@ -0,0 +1,101 @@
+package clusteradmin
+
+import (
+	"testing"
+
+	"github.com/go-redis/redis"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestGinkgoSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "clusteradmin")
+}
+
+func master(id string, ranges ...redis.ClusterSlotRange) redis.ClusterNodeInfo {
+	return redis.ClusterNodeInfo{
+		Id:        id,
+		Flags:     []string{"master"},
+		LinkState: "connected",
+		Slots:     ranges,
+	}
+}
+
+func countSlots(moves []slotMove) map[string]int {
+	m := make(map[string]int)
+	for _, move := range moves {
+		m[move.source]--
+		m[move.target]++
+	}
+	return m
+}
+
+var _ = Describe("planRebalance", func() {
+	It("does nothing for balanced cluster", func() {
+		nodes := []redis.ClusterNodeInfo{
+			master("a", redis.ClusterSlotRange{Start: 0, End: 8191}),
+			master("b", redis.ClusterSlotRange{Start: 8192, End: 16383}),
+		}
+		Expect(planRebalance(nodes)).To(BeEmpty())
+	})
+
+	It("gives empty master its share", func() {
+		nodes := []redis.ClusterNodeInfo{
+			master("a", redis.ClusterSlotRange{Start: 0, End: 8191}),
+			master("b", redis.ClusterSlotRange{Start: 8192, End: 16383}),
+			master("c"),
+		}
+
+		moves := planRebalance(nodes)
+		Expect(moves).To(HaveLen(5461))
+		Expect(countSlots(moves)).To(Equal(map[string]int{
+			"a": -2730,
+			"b": -2731,
+			"c": 5461,
+		}))
+
+		// Donors give away their highest slots.
+		Expect(moves[0]).To(Equal(slotMove{slot: 5462, source: "a", target: "c"}))
+		Expect(moves[len(moves)-1]).To(Equal(slotMove{slot: 16383, source: "b", target: "c"}))
+	})
+
+	It("ignores slaves and failed masters", func() {
+		failed := master("c")
+		failed.Flags = []string{"master", "fail"}
+		slave := redis.ClusterNodeInfo{
+			Id:       "d",
+			Flags:    []string{"slave"},
+			MasterId: "a",
+		}
+		nodes := []redis.ClusterNodeInfo{
+			master("a", redis.ClusterSlotRange{Start: 0, End: 16383}),
+			master("b"),
+			failed,
+			slave,
+		}
+
+		Expect(countSlots(planRebalance(nodes))).To(Equal(map[string]int{
+			"a": -8192,
+			"b": 8192,
+		}))
+	})
+})
+
+var _ = Describe("slotOwners", func() {
+	It("maps slots to masters", func() {
+		nodes := []redis.ClusterNodeInfo{
+			master("a", redis.ClusterSlotRange{Start: 0, End: 10}, redis.ClusterSlotRange{Start: 20, End: 20}),
+			master("b", redis.ClusterSlotRange{Start: 11, End: 19}),
+		}
+
+		owners := slotOwners(nodes)
+		Expect(owners).To(HaveLen(SlotsNumber))
+		Expect(owners[0]).To(Equal("a"))
+		Expect(owners[10]).To(Equal("a"))
+		Expect(owners[11]).To(Equal("b"))
+		Expect(owners[20]).To(Equal("a"))
+		Expect(owners[21]).To(Equal(""))
+	})
+})
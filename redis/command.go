@@ -2,6 +2,7 @@ package redis
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -86,8 +87,23 @@ func cmdFirstKeyPos(cmd Cmder, info *CommandInfo) int {
 		}
 
 		return 0
-	case "publish":
+	case "publish", "spublish":
 		return 1
+	case "zunion", "zinter", "zdiff", "lmpop", "sintercard":
+		// ZUNION numkeys key [key ...]
+		return 2
+	case "blmpop":
+		// BLMPOP timeout numkeys key [key ...]
+		return 3
+	case "migrate":
+		// MIGRATE host port "" db timeout ... KEYS key [key ...]
+		if cmd.stringArg(3) == "" {
+			for i := 6; i < len(cmd.Args()); i++ {
+				if cmd.stringArg(i) == "keys" {
+					return i + 1
+				}
+			}
+		}
 	}
 	if info == nil {
 		return 0
@@ -601,379 +617,1555 @@ func (cmd *BoolSliceCmd) readReply(cn *pool.Conn) error {
 
 //------------------------------------------------------------------------------
 
-type StringStringMapCmd struct {
+// KeyValuesCmd is used for commands that pop values from the first
+// non-empty key, e.g. LMPOP.
+type KeyValuesCmd struct {
 	baseCmd
 
-	val map[string]string
+	key string
+	val []string
 }
 
-var _ Cmder = (*StringStringMapCmd)(nil)
+var _ Cmder = (*KeyValuesCmd)(nil)
 
-func NewStringStringMapCmd(args ...interface{}) *StringStringMapCmd {
-	return &StringStringMapCmd{
+func NewKeyValuesCmd(args ...interface{}) *KeyValuesCmd {
+	return &KeyValuesCmd{
 		baseCmd: baseCmd{_args: args},
 	}
 }
 
-func (cmd *StringStringMapCmd) Val() map[string]string {
-	return cmd.val
+func (cmd *KeyValuesCmd) Val() (string, []string) {
+	return cmd.key, cmd.val
 }
 
-func (cmd *StringStringMapCmd) Result() (map[string]string, error) {
-	return cmd.val, cmd.err
+func (cmd *KeyValuesCmd) Result() (string, []string, error) {
+	return cmd.key, cmd.val, cmd.err
 }
 
-func (cmd *StringStringMapCmd) String() string {
+func (cmd *KeyValuesCmd) String() string {
 	return cmdString(cmd, cmd.val)
 }
 
-func (cmd *StringStringMapCmd) readReply(cn *pool.Conn) error {
+func (cmd *KeyValuesCmd) readReply(cn *pool.Conn) error {
 	var v interface{}
-	v, cmd.err = cn.Rd.ReadArrayReply(stringStringMapParser)
+	v, cmd.err = cn.Rd.ReadArrayReply(keyValuesParser)
 	if cmd.err != nil {
 		return cmd.err
 	}
-	cmd.val = v.(map[string]string)
+	kv := v.(*keyValues)
+	cmd.key = kv.key
+	cmd.val = kv.vals
 	return nil
 }
 
 //------------------------------------------------------------------------------
 
-type StringIntMapCmd struct {
+type IntSliceCmd struct {
 	baseCmd
 
-	val map[string]int64
+	val  []int64
+	nils []bool
 }
 
-var _ Cmder = (*StringIntMapCmd)(nil)
+var _ Cmder = (*IntSliceCmd)(nil)
 
-func NewStringIntMapCmd(args ...interface{}) *StringIntMapCmd {
-	return &StringIntMapCmd{
+func NewIntSliceCmd(args ...interface{}) *IntSliceCmd {
+	return &IntSliceCmd{
 		baseCmd: baseCmd{_args: args},
 	}
 }
 
-func (cmd *StringIntMapCmd) Val() map[string]int64 {
+// Val returns replies with nil replies reported as 0.
+func (cmd *IntSliceCmd) Val() []int64 {
 	return cmd.val
 }
 
-func (cmd *StringIntMapCmd) Result() (map[string]int64, error) {
+func (cmd *IntSliceCmd) Result() ([]int64, error) {
 	return cmd.val, cmd.err
 }
 
-func (cmd *StringIntMapCmd) String() string {
+// Nil reports whether the i-th reply is nil.
+func (cmd *IntSliceCmd) Nil(i int) bool {
+	return i < len(cmd.nils) && cmd.nils[i]
+}
+
+func (cmd *IntSliceCmd) String() string {
 	return cmdString(cmd, cmd.val)
 }
 
-func (cmd *StringIntMapCmd) readReply(cn *pool.Conn) error {
+func (cmd *IntSliceCmd) readReply(cn *pool.Conn) error {
 	var v interface{}
-	v, cmd.err = cn.Rd.ReadArrayReply(stringIntMapParser)
+	v, cmd.err = cn.Rd.ReadArrayReply(intSliceParser)
 	if cmd.err != nil {
 		return cmd.err
 	}
-	cmd.val = v.(map[string]int64)
+	ints := v.(*intSlice)
+	cmd.val = ints.vals
+	cmd.nils = ints.nils
 	return nil
 }
 
 //------------------------------------------------------------------------------
 
-type StringStructMapCmd struct {
+type FloatSliceCmd struct {
 	baseCmd
 
-	val map[string]struct{}
+	val  []float64
+	nils []bool
 }
 
-var _ Cmder = (*StringStructMapCmd)(nil)
+var _ Cmder = (*FloatSliceCmd)(nil)
 
-func NewStringStructMapCmd(args ...interface{}) *StringStructMapCmd {
-	return &StringStructMapCmd{
+func NewFloatSliceCmd(args ...interface{}) *FloatSliceCmd {
+	return &FloatSliceCmd{
 		baseCmd: baseCmd{_args: args},
 	}
 }
 
-func (cmd *StringStructMapCmd) Val() map[string]struct{} {
+// Val returns replies with nil replies reported as 0.
+func (cmd *FloatSliceCmd) Val() []float64 {
 	return cmd.val
 }
 
-func (cmd *StringStructMapCmd) Result() (map[string]struct{}, error) {
+func (cmd *FloatSliceCmd) Result() ([]float64, error) {
 	return cmd.val, cmd.err
 }
 
-func (cmd *StringStructMapCmd) String() string {
+// Nil reports whether the i-th reply is nil.
+func (cmd *FloatSliceCmd) Nil(i int) bool {
+	return i < len(cmd.nils) && cmd.nils[i]
+}
+
+func (cmd *FloatSliceCmd) String() string {
 	return cmdString(cmd, cmd.val)
 }
 
-func (cmd *StringStructMapCmd) readReply(cn *pool.Conn) error {
+func (cmd *FloatSliceCmd) readReply(cn *pool.Conn) error {
 	var v interface{}
-	v, cmd.err = cn.Rd.ReadArrayReply(stringStructMapParser)
+	v, cmd.err = cn.Rd.ReadArrayReply(floatSliceParser)
 	if cmd.err != nil {
 		return cmd.err
 	}
-	cmd.val = v.(map[string]struct{})
+	floats := v.(*floatSlice)
+	cmd.val = floats.vals
+	cmd.nils = floats.nils
 	return nil
 }
 
 //------------------------------------------------------------------------------
 
-type ZSliceCmd struct {
+// ClientInfo is a connection reported by CLIENT LIST and CLIENT INFO
+// commands, see https://redis.io/commands/client-list.
+type ClientInfo struct {
+	ID int64
+	// Address of the client.
+	Addr string
+	// Address of the server the client is connected to.
+	LAddr string
+	FD    int64
+	Name  string
+	Age   time.Duration
+	Idle  time.Duration
+	// Flags, e.g. N for normal clients or S for replicas.
+	Flags string
+	DB    int
+	// Number of channel, pattern and shard channel subscriptions.
+	Sub  int64
+	PSub int64
+	SSub int64
+	// Number of commands in MULTI, -1 outside of MULTI.
+	Multi int64
+	// Query buffer length and free space in bytes.
+	QueryBuf     int64
+	QueryBufFree int64
+	// Output buffer length in bytes and number of queued replies.
+	OutputBuf     int64
+	OutputListLen int64
+	// Memory used by output buffer and in total.
+	OutputMemory int64
+	TotalMemory  int64
+	// File descriptor events: r, w or both.
+	Events string
+	// Last command run by the client.
+	LastCmd string
+	User    string
+	// ID of the client that receives tracking notifications.
+	Redir int64
+	// Protocol version.
+	Resp int
+}
+
+// ParseClientInfo parses a line of CLIENT LIST or CLIENT INFO reply.
+func ParseClientInfo(line string) (*ClientInfo, error) {
+	info := new(ClientInfo)
+	for _, field := range strings.Fields(line) {
+		i := strings.IndexByte(field, '=')
+		if i == -1 {
+			return nil, fmt.Errorf("redis: invalid client info field: %q", field)
+		}
+		key, val := field[:i], field[i+1:]
+
+		var err error
+		switch key {
+		case "id":
+			info.ID, err = strconv.ParseInt(val, 10, 64)
+		case "addr":
+			info.Addr = val
+		case "laddr":
+			info.LAddr = val
+		case "fd":
+			info.FD, err = strconv.ParseInt(val, 10, 64)
+		case "name":
+			info.Name = val
+		case "age":
+			info.Age, err = parseSeconds(val)
+		case "idle":
+			info.Idle, err = parseSeconds(val)
+		case "flags":
+			info.Flags = val
+		case "db":
+			info.DB, err = strconv.Atoi(val)
+		case "sub":
+			info.Sub, err = strconv.ParseInt(val, 10, 64)
+		case "psub":
+			info.PSub, err = strconv.ParseInt(val, 10, 64)
+		case "ssub":
+			info.SSub, err = strconv.ParseInt(val, 10, 64)
+		case "multi":
+			info.Multi, err = strconv.ParseInt(val, 10, 64)
+		case "qbuf":
+			info.QueryBuf, err = strconv.ParseInt(val, 10, 64)
+		case "qbuf-free":
+			info.QueryBufFree, err = strconv.ParseInt(val, 10, 64)
+		case "obl":
+			info.OutputBuf, err = strconv.ParseInt(val, 10, 64)
+		case "oll":
+			info.OutputListLen, err = strconv.ParseInt(val, 10, 64)
+		case "omem":
+			info.OutputMemory, err = strconv.ParseInt(val, 10, 64)
+		case "tot-mem":
+			info.TotalMemory, err = strconv.ParseInt(val, 10, 64)
+		case "events":
+			info.Events = val
+		case "cmd":
+			info.LastCmd = val
+		case "user":
+			info.User = val
+		case "redir":
+			info.Redir, err = strconv.ParseInt(val, 10, 64)
+		case "resp":
+			info.Resp, err = strconv.Atoi(val)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid client info field: %q", field)
+		}
+	}
+	return info, nil
+}
+
+func parseSeconds(s string) (time.Duration, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(n) * time.Second, nil
+}
+
+type ClientInfoCmd struct {
 	baseCmd
 
-	val []Z
+	val *ClientInfo
 }
 
-var _ Cmder = (*ZSliceCmd)(nil)
+var _ Cmder = (*ClientInfoCmd)(nil)
 
-func NewZSliceCmd(args ...interface{}) *ZSliceCmd {
-	return &ZSliceCmd{
+func NewClientInfoCmd(args ...interface{}) *ClientInfoCmd {
+	return &ClientInfoCmd{
 		baseCmd: baseCmd{_args: args},
 	}
 }
 
-func (cmd *ZSliceCmd) Val() []Z {
+func (cmd *ClientInfoCmd) Val() *ClientInfo {
 	return cmd.val
 }
 
-func (cmd *ZSliceCmd) Result() ([]Z, error) {
+func (cmd *ClientInfoCmd) Result() (*ClientInfo, error) {
 	return cmd.val, cmd.err
 }
 
-func (cmd *ZSliceCmd) String() string {
+func (cmd *ClientInfoCmd) String() string {
 	return cmdString(cmd, cmd.val)
 }
 
-func (cmd *ZSliceCmd) readReply(cn *pool.Conn) error {
-	var v interface{}
-	v, cmd.err = cn.Rd.ReadArrayReply(zSliceParser)
+func (cmd *ClientInfoCmd) readReply(cn *pool.Conn) error {
+	var s string
+	s, cmd.err = cn.Rd.ReadStringReply()
 	if cmd.err != nil {
 		return cmd.err
 	}
-	cmd.val = v.([]Z)
-	return nil
+	cmd.val, cmd.err = ParseClientInfo(s)
+	return cmd.err
 }
 
 //------------------------------------------------------------------------------
 
-type ScanCmd struct {
+type ClientInfoSliceCmd struct {
 	baseCmd
 
-	page   []string
-	cursor uint64
-
-	process func(cmd Cmder) error
+	val []*ClientInfo
 }
 
-var _ Cmder = (*ScanCmd)(nil)
+var _ Cmder = (*ClientInfoSliceCmd)(nil)
 
-func NewScanCmd(process func(cmd Cmder) error, args ...interface{}) *ScanCmd {
-	return &ScanCmd{
+func NewClientInfoSliceCmd(args ...interface{}) *ClientInfoSliceCmd {
+	return &ClientInfoSliceCmd{
 		baseCmd: baseCmd{_args: args},
-		process: process,
 	}
 }
 
-func (cmd *ScanCmd) Val() (keys []string, cursor uint64) {
-	return cmd.page, cmd.cursor
+func (cmd *ClientInfoSliceCmd) Val() []*ClientInfo {
+	return cmd.val
 }
 
-func (cmd *ScanCmd) Result() (keys []string, cursor uint64, err error) {
-	return cmd.page, cmd.cursor, cmd.err
+func (cmd *ClientInfoSliceCmd) Result() ([]*ClientInfo, error) {
+	return cmd.val, cmd.err
 }
 
-func (cmd *ScanCmd) String() string {
-	return cmdString(cmd, cmd.page)
+func (cmd *ClientInfoSliceCmd) String() string {
+	return cmdString(cmd, cmd.val)
 }
 
-func (cmd *ScanCmd) readReply(cn *pool.Conn) error {
-	cmd.page, cmd.cursor, cmd.err = cn.Rd.ReadScanReply()
-	return cmd.err
-}
+func (cmd *ClientInfoSliceCmd) readReply(cn *pool.Conn) error {
+	var s string
+	s, cmd.err = cn.Rd.ReadStringReply()
+	if cmd.err != nil {
+		return cmd.err
+	}
 
-// Iterator creates a new ScanIterator.
-func (cmd *ScanCmd) Iterator() *ScanIterator {
-	return &ScanIterator{
-		cmd: cmd,
+	var infos []*ClientInfo
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		info, err := ParseClientInfo(line)
+		if err != nil {
+			cmd.err = err
+			return err
+		}
+		infos = append(infos, info)
 	}
+	cmd.val = infos
+	return nil
 }
 
 //------------------------------------------------------------------------------
 
-type ClusterNode struct {
-	Id   string
-	Addr string
+// Info is INFO command reply parsed into sections, e.g. "memory" or
+// "keyspace", with fields of every section.
+type Info map[string]map[string]string
+
+func parseInfo(s string) Info {
+	info := make(Info)
+	var section map[string]string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if line == "" {
+			continue
+		}
+
+		if line[0] == '#' {
+			name := strings.ToLower(strings.TrimSpace(line[1:]))
+			section = make(map[string]string)
+			info[name] = section
+			continue
+		}
+
+		i := strings.IndexByte(line, ':')
+		if i == -1 {
+			continue
+		}
+		if section == nil {
+			section = make(map[string]string)
+			info[""] = section
+		}
+		section[line[:i]] = line[i+1:]
+	}
+	return info
 }
 
-type ClusterSlot struct {
-	Start int
-	End   int
-	Nodes []ClusterNode
+// Field returns the field value or empty string if there is no such field.
+func (info Info) Field(section, field string) string {
+	return info[section][field]
 }
 
-type ClusterSlotsCmd struct {
+func (info Info) intField(section, field string) int64 {
+	n, _ := strconv.ParseInt(info.Field(section, field), 10, 64)
+	return n
+}
+
+// UsedMemory returns used_memory field in bytes.
+func (info Info) UsedMemory() int64 {
+	return info.intField("memory", "used_memory")
+}
+
+func (info Info) ConnectedClients() int64 {
+	return info.intField("clients", "connected_clients")
+}
+
+// Role returns master or slave.
+func (info Info) Role() string {
+	return info.Field("replication", "role")
+}
+
+// MasterLinkStatus returns up or down for slaves
+// and empty string for masters.
+func (info Info) MasterLinkStatus() string {
+	return info.Field("replication", "master_link_status")
+}
+
+// KeyspaceInfo is the keyspace statistics of a database.
+type KeyspaceInfo struct {
+	Keys    int64
+	Expires int64
+	AvgTTL  time.Duration
+}
+
+// Keyspace returns statistics of the databases that have keys
+// by the database number.
+func (info Info) Keyspace() map[int]KeyspaceInfo {
+	m := make(map[int]KeyspaceInfo)
+	for name, value := range info["keyspace"] {
+		if !strings.HasPrefix(name, "db") {
+			continue
+		}
+		db, err := strconv.Atoi(name[2:])
+		if err != nil {
+			continue
+		}
+
+		var ks KeyspaceInfo
+		// db0:keys=1,expires=0,avg_ttl=0
+		for _, kv := range strings.Split(value, ",") {
+			i := strings.IndexByte(kv, '=')
+			if i == -1 {
+				continue
+			}
+			n, _ := strconv.ParseInt(kv[i+1:], 10, 64)
+			switch kv[:i] {
+			case "keys":
+				ks.Keys = n
+			case "expires":
+				ks.Expires = n
+			case "avg_ttl":
+				ks.AvgTTL = time.Duration(n) * time.Millisecond
+			}
+		}
+		m[db] = ks
+	}
+	return m
+}
+
+type InfoCmd struct {
 	baseCmd
 
-	val []ClusterSlot
+	val Info
 }
 
-var _ Cmder = (*ClusterSlotsCmd)(nil)
+var _ Cmder = (*InfoCmd)(nil)
 
-func NewClusterSlotsCmd(args ...interface{}) *ClusterSlotsCmd {
-	return &ClusterSlotsCmd{
+func NewInfoCmd(args ...interface{}) *InfoCmd {
+	return &InfoCmd{
 		baseCmd: baseCmd{_args: args},
 	}
 }
 
-func (cmd *ClusterSlotsCmd) Val() []ClusterSlot {
+func (cmd *InfoCmd) Val() Info {
 	return cmd.val
 }
 
-func (cmd *ClusterSlotsCmd) Result() ([]ClusterSlot, error) {
-	return cmd.Val(), cmd.Err()
+func (cmd *InfoCmd) Result() (Info, error) {
+	return cmd.val, cmd.err
 }
 
-func (cmd *ClusterSlotsCmd) String() string {
+func (cmd *InfoCmd) String() string {
 	return cmdString(cmd, cmd.val)
 }
 
-func (cmd *ClusterSlotsCmd) readReply(cn *pool.Conn) error {
-	var v interface{}
-	v, cmd.err = cn.Rd.ReadArrayReply(clusterSlotsParser)
+func (cmd *InfoCmd) readReply(cn *pool.Conn) error {
+	var s string
+	s, cmd.err = cn.Rd.ReadStringReply()
 	if cmd.err != nil {
 		return cmd.err
 	}
-	cmd.val = v.([]ClusterSlot)
+	cmd.val = parseInfo(s)
 	return nil
 }
 
 //------------------------------------------------------------------------------
 
-// GeoLocation is used with GeoAdd to add geospatial location.
-type GeoLocation struct {
-	Name                      string
-	Longitude, Latitude, Dist float64
-	GeoHash                   int64
-}
-
-// GeoRadiusQuery is used with GeoRadius to query geospatial index.
-type GeoRadiusQuery struct {
-	Radius float64
-	// Can be m, km, ft, or mi. Default is km.
-	Unit        string
-	WithCoord   bool
-	WithDist    bool
-	WithGeoHash bool
-	Count       int
-	// Can be ASC or DESC. Default is no sort order.
-	Sort      string
-	Store     string
-	StoreDist string
-}
-
-type GeoLocationCmd struct {
+// InfoMapCmd is used for INFO sent to every cluster node by
+// ClusterClient.InfoMap, replies are keyed by node address.
+type InfoMapCmd struct {
 	baseCmd
 
-	q         *GeoRadiusQuery
-	locations []GeoLocation
+	val map[string]Info
 }
 
-var _ Cmder = (*GeoLocationCmd)(nil)
+var _ Cmder = (*InfoMapCmd)(nil)
 
-func NewGeoLocationCmd(q *GeoRadiusQuery, args ...interface{}) *GeoLocationCmd {
-	args = append(args, q.Radius)
-	if q.Unit != "" {
-		args = append(args, q.Unit)
-	} else {
-		args = append(args, "km")
-	}
-	if q.WithCoord {
-		args = append(args, "withcoord")
-	}
-	if q.WithDist {
-		args = append(args, "withdist")
-	}
-	if q.WithGeoHash {
-		args = append(args, "withhash")
-	}
-	if q.Count > 0 {
-		args = append(args, "count", q.Count)
-	}
-	if q.Sort != "" {
-		args = append(args, q.Sort)
-	}
-	if q.Store != "" {
-		args = append(args, "store")
-		args = append(args, q.Store)
-	}
-	if q.StoreDist != "" {
-		args = append(args, "storedist")
-		args = append(args, q.StoreDist)
-	}
-	return &GeoLocationCmd{
+func NewInfoMapCmd(args ...interface{}) *InfoMapCmd {
+	return &InfoMapCmd{
 		baseCmd: baseCmd{_args: args},
-		q:       q,
 	}
 }
 
-func (cmd *GeoLocationCmd) Val() []GeoLocation {
-	return cmd.locations
+func (cmd *InfoMapCmd) Val() map[string]Info {
+	return cmd.val
 }
 
-func (cmd *GeoLocationCmd) Result() ([]GeoLocation, error) {
-	return cmd.locations, cmd.err
+func (cmd *InfoMapCmd) Result() (map[string]Info, error) {
+	return cmd.val, cmd.err
 }
 
-func (cmd *GeoLocationCmd) String() string {
-	return cmdString(cmd, cmd.locations)
+func (cmd *InfoMapCmd) String() string {
+	return cmdString(cmd, cmd.val)
 }
 
-func (cmd *GeoLocationCmd) readReply(cn *pool.Conn) error {
-	var v interface{}
-	v, cmd.err = cn.Rd.ReadArrayReply(newGeoLocationSliceParser(cmd.q))
+func (cmd *InfoMapCmd) readReply(cn *pool.Conn) error {
+	_, cmd.err = cn.Rd.ReadStringReply()
 	if cmd.err != nil {
 		return cmd.err
 	}
-	cmd.locations = v.([]GeoLocation)
-	return nil
+	// Node address is known only to ClusterClient.
+	cmd.err = errNodeMapCmd
+	return cmd.err
 }
 
-//------------------------------------------------------------------------------
+var errNodeMapCmd = errors.New("redis: command can only be sent with ClusterClient")
 
-type GeoPos struct {
-	Longitude, Latitude float64
-}
+//------------------------------------------------------------------------------
 
-type GeoPosCmd struct {
+type StringStringMapCmd struct {
 	baseCmd
 
-	positions []*GeoPos
+	val map[string]string
 }
 
-var _ Cmder = (*GeoPosCmd)(nil)
+var _ Cmder = (*StringStringMapCmd)(nil)
 
-func NewGeoPosCmd(args ...interface{}) *GeoPosCmd {
-	return &GeoPosCmd{
+func NewStringStringMapCmd(args ...interface{}) *StringStringMapCmd {
+	return &StringStringMapCmd{
 		baseCmd: baseCmd{_args: args},
 	}
 }
 
-func (cmd *GeoPosCmd) Val() []*GeoPos {
-	return cmd.positions
+func (cmd *StringStringMapCmd) Val() map[string]string {
+	return cmd.val
 }
 
-func (cmd *GeoPosCmd) Result() ([]*GeoPos, error) {
-	return cmd.Val(), cmd.Err()
+func (cmd *StringStringMapCmd) Result() (map[string]string, error) {
+	return cmd.val, cmd.err
 }
 
-func (cmd *GeoPosCmd) String() string {
-	return cmdString(cmd, cmd.positions)
+func (cmd *StringStringMapCmd) String() string {
+	return cmdString(cmd, cmd.val)
 }
 
-func (cmd *GeoPosCmd) readReply(cn *pool.Conn) error {
+func (cmd *StringStringMapCmd) readReply(cn *pool.Conn) error {
 	var v interface{}
-	v, cmd.err = cn.Rd.ReadArrayReply(geoPosSliceParser)
+	v, cmd.err = cn.Rd.ReadArrayReply(stringStringMapParser)
 	if cmd.err != nil {
 		return cmd.err
 	}
-	cmd.positions = v.([]*GeoPos)
+	cmd.val = v.(map[string]string)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type StringIntMapCmd struct {
+	baseCmd
+
+	val map[string]int64
+}
+
+var _ Cmder = (*StringIntMapCmd)(nil)
+
+func NewStringIntMapCmd(args ...interface{}) *StringIntMapCmd {
+	return &StringIntMapCmd{
+		baseCmd: baseCmd{_args: args},
+	}
+}
+
+func (cmd *StringIntMapCmd) Val() map[string]int64 {
+	return cmd.val
+}
+
+func (cmd *StringIntMapCmd) Result() (map[string]int64, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *StringIntMapCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *StringIntMapCmd) readReply(cn *pool.Conn) error {
+	var v interface{}
+	v, cmd.err = cn.Rd.ReadArrayReply(stringIntMapParser)
+	if cmd.err != nil {
+		return cmd.err
+	}
+	cmd.val = v.(map[string]int64)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type StringStructMapCmd struct {
+	baseCmd
+
+	val map[string]struct{}
+}
+
+var _ Cmder = (*StringStructMapCmd)(nil)
+
+func NewStringStructMapCmd(args ...interface{}) *StringStructMapCmd {
+	return &StringStructMapCmd{
+		baseCmd: baseCmd{_args: args},
+	}
+}
+
+func (cmd *StringStructMapCmd) Val() map[string]struct{} {
+	return cmd.val
+}
+
+func (cmd *StringStructMapCmd) Result() (map[string]struct{}, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *StringStructMapCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *StringStructMapCmd) readReply(cn *pool.Conn) error {
+	var v interface{}
+	v, cmd.err = cn.Rd.ReadArrayReply(stringStructMapParser)
+	if cmd.err != nil {
+		return cmd.err
+	}
+	cmd.val = v.(map[string]struct{})
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type StringStringMapSliceCmd struct {
+	baseCmd
+
+	val []map[string]string
+}
+
+var _ Cmder = (*StringStringMapSliceCmd)(nil)
+
+func NewStringStringMapSliceCmd(args ...interface{}) *StringStringMapSliceCmd {
+	return &StringStringMapSliceCmd{
+		baseCmd: baseCmd{_args: args},
+	}
+}
+
+func (cmd *StringStringMapSliceCmd) Val() []map[string]string {
+	return cmd.val
+}
+
+func (cmd *StringStringMapSliceCmd) Result() ([]map[string]string, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *StringStringMapSliceCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *StringStringMapSliceCmd) readReply(cn *pool.Conn) error {
+	var v interface{}
+	v, cmd.err = cn.Rd.ReadArrayReply(stringStringMapSliceParser)
+	if cmd.err != nil {
+		return cmd.err
+	}
+	cmd.val = v.([]map[string]string)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type ZSliceCmd struct {
+	baseCmd
+
+	val []Z
+}
+
+var _ Cmder = (*ZSliceCmd)(nil)
+
+func NewZSliceCmd(args ...interface{}) *ZSliceCmd {
+	return &ZSliceCmd{
+		baseCmd: baseCmd{_args: args},
+	}
+}
+
+func (cmd *ZSliceCmd) Val() []Z {
+	return cmd.val
+}
+
+func (cmd *ZSliceCmd) Result() ([]Z, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *ZSliceCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *ZSliceCmd) readReply(cn *pool.Conn) error {
+	var v interface{}
+	v, cmd.err = cn.Rd.ReadArrayReply(zSliceParser)
+	if cmd.err != nil {
+		return cmd.err
+	}
+	cmd.val = v.([]Z)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type ZWithKeyCmd struct {
+	baseCmd
+
+	val ZWithKey
+}
+
+var _ Cmder = (*ZWithKeyCmd)(nil)
+
+func NewZWithKeyCmd(args ...interface{}) *ZWithKeyCmd {
+	return &ZWithKeyCmd{
+		baseCmd: baseCmd{_args: args},
+	}
+}
+
+func (cmd *ZWithKeyCmd) Val() ZWithKey {
+	return cmd.val
+}
+
+func (cmd *ZWithKeyCmd) Result() (ZWithKey, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *ZWithKeyCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *ZWithKeyCmd) readReply(cn *pool.Conn) error {
+	var v interface{}
+	v, cmd.err = cn.Rd.ReadArrayReply(zWithKeyParser)
+	if cmd.err != nil {
+		return cmd.err
+	}
+	cmd.val = v.(ZWithKey)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// LCSPosition is a range of a string matched by LCS IDX,
+// both ends are inclusive.
+type LCSPosition struct {
+	Start int64
+	End   int64
+}
+
+// LCSMatch is a common part of two strings.
+type LCSMatch struct {
+	A   LCSPosition
+	B   LCSPosition
+	Len int64
+}
+
+// LCSIdx is the reply of LCS IDX command.
+type LCSIdx struct {
+	Matches []LCSMatch
+	Len     int64
+}
+
+type LCSIdxCmd struct {
+	baseCmd
+
+	val LCSIdx
+}
+
+var _ Cmder = (*LCSIdxCmd)(nil)
+
+func NewLCSIdxCmd(args ...interface{}) *LCSIdxCmd {
+	return &LCSIdxCmd{
+		baseCmd: baseCmd{_args: args},
+	}
+}
+
+func (cmd *LCSIdxCmd) Val() LCSIdx {
+	return cmd.val
+}
+
+func (cmd *LCSIdxCmd) Result() (LCSIdx, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *LCSIdxCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *LCSIdxCmd) readReply(cn *pool.Conn) error {
+	var v interface{}
+	v, cmd.err = cn.Rd.ReadArrayReply(lcsIdxParser)
+	if cmd.err != nil {
+		return cmd.err
+	}
+	cmd.val = v.(LCSIdx)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type ScanCmd struct {
+	baseCmd
+
+	page   []string
+	cursor uint64
+
+	process func(cmd Cmder) error
+}
+
+var _ Cmder = (*ScanCmd)(nil)
+
+func NewScanCmd(process func(cmd Cmder) error, args ...interface{}) *ScanCmd {
+	return &ScanCmd{
+		baseCmd: baseCmd{_args: args},
+		process: process,
+	}
+}
+
+func (cmd *ScanCmd) Val() (keys []string, cursor uint64) {
+	return cmd.page, cmd.cursor
+}
+
+func (cmd *ScanCmd) Result() (keys []string, cursor uint64, err error) {
+	return cmd.page, cmd.cursor, cmd.err
+}
+
+func (cmd *ScanCmd) String() string {
+	return cmdString(cmd, cmd.page)
+}
+
+func (cmd *ScanCmd) readReply(cn *pool.Conn) error {
+	cmd.page, cmd.cursor, cmd.err = cn.Rd.ReadScanReply()
+	return cmd.err
+}
+
+// Iterator creates a new ScanIterator.
+func (cmd *ScanCmd) Iterator() *ScanIterator {
+	return &ScanIterator{
+		cmd: cmd,
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// SentinelInfoCache is INFO reply of a master or replica cached
+// by Sentinel.
+type SentinelInfoCache struct {
+	// Age of the reply.
+	Age time.Duration
+	// Info is empty if Sentinel has not received the reply yet.
+	Info string
+}
+
+type SentinelInfoCacheCmd struct {
+	baseCmd
+
+	val map[string][]SentinelInfoCache
+}
+
+var _ Cmder = (*SentinelInfoCacheCmd)(nil)
+
+func NewSentinelInfoCacheCmd(args ...interface{}) *SentinelInfoCacheCmd {
+	return &SentinelInfoCacheCmd{
+		baseCmd: baseCmd{_args: args},
+	}
+}
+
+// Val returns cached replies keyed by master name. Reply of the master
+// goes first and is followed by replies of its replicas.
+func (cmd *SentinelInfoCacheCmd) Val() map[string][]SentinelInfoCache {
+	return cmd.val
+}
+
+func (cmd *SentinelInfoCacheCmd) Result() (map[string][]SentinelInfoCache, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *SentinelInfoCacheCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *SentinelInfoCacheCmd) readReply(cn *pool.Conn) error {
+	var v interface{}
+	v, cmd.err = cn.Rd.ReadArrayReply(sentinelInfoCacheParser)
+	if cmd.err != nil {
+		return cmd.err
+	}
+	cmd.val = v.(map[string][]SentinelInfoCache)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type ClusterNode struct {
+	Id   string
+	Addr string
+}
+
+type ClusterSlot struct {
+	Start int
+	End   int
+	Nodes []ClusterNode
+}
+
+type ClusterSlotsCmd struct {
+	baseCmd
+
+	val []ClusterSlot
+}
+
+var _ Cmder = (*ClusterSlotsCmd)(nil)
+
+func NewClusterSlotsCmd(args ...interface{}) *ClusterSlotsCmd {
+	return &ClusterSlotsCmd{
+		baseCmd: baseCmd{_args: args},
+	}
+}
+
+func (cmd *ClusterSlotsCmd) Val() []ClusterSlot {
+	return cmd.val
+}
+
+func (cmd *ClusterSlotsCmd) Result() ([]ClusterSlot, error) {
+	return cmd.Val(), cmd.Err()
+}
+
+func (cmd *ClusterSlotsCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *ClusterSlotsCmd) readReply(cn *pool.Conn) error {
+	var v interface{}
+	v, cmd.err = cn.Rd.ReadArrayReply(clusterSlotsParser)
+	if cmd.err != nil {
+		return cmd.err
+	}
+	cmd.val = v.([]ClusterSlot)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// ClusterSlotRange is a range of slots served by a node. Start and End
+// are inclusive.
+type ClusterSlotRange struct {
+	Start int
+	End   int
+}
+
+// ClusterNodeInfo describes a node as reported by CLUSTER NODES.
+type ClusterNodeInfo struct {
+	Id   string
+	Addr string
+	// Can contain myself, master, slave, fail?, fail, handshake,
+	// noaddr and noflags.
+	Flags []string
+	// Id of the master node for slaves. Empty for masters.
+	MasterId    string
+	PingSent    int64
+	PongRecv    int64
+	ConfigEpoch int64
+	// Can be connected or disconnected.
+	LinkState string
+	Slots     []ClusterSlotRange
+	// Slots being migrated to other nodes, keyed by slot.
+	Migrating map[int]string
+	// Slots being imported from other nodes, keyed by slot.
+	Importing map[int]string
+}
+
+// HasFlag reports whether the node has the flag set.
+func (n *ClusterNodeInfo) HasFlag(flag string) bool {
+	for _, f := range n.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *ClusterNodeInfo) IsMaster() bool {
+	return n.HasFlag("master")
+}
+
+func (n *ClusterNodeInfo) IsSlave() bool {
+	return n.HasFlag("slave")
+}
+
+// IsHealthy reports whether the node is reachable and is not flagged
+// as failing or in handshake.
+func (n *ClusterNodeInfo) IsHealthy() bool {
+	if n.HasFlag("fail") || n.HasFlag("fail?") ||
+		n.HasFlag("handshake") || n.HasFlag("noaddr") {
+		return false
+	}
+	return n.LinkState == "connected"
+}
+
+type ClusterNodesCmd struct {
+	baseCmd
+
+	val []ClusterNodeInfo
+}
+
+var _ Cmder = (*ClusterNodesCmd)(nil)
+
+func NewClusterNodesCmd(args ...interface{}) *ClusterNodesCmd {
+	return &ClusterNodesCmd{
+		baseCmd: baseCmd{_args: args},
+	}
+}
+
+func (cmd *ClusterNodesCmd) Val() []ClusterNodeInfo {
+	return cmd.val
+}
+
+func (cmd *ClusterNodesCmd) Result() ([]ClusterNodeInfo, error) {
+	return cmd.Val(), cmd.Err()
+}
+
+func (cmd *ClusterNodesCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *ClusterNodesCmd) readReply(cn *pool.Conn) error {
+	var b []byte
+	b, cmd.err = cn.Rd.ReadTmpBytesReply()
+	if cmd.err != nil {
+		return cmd.err
+	}
+	cmd.val, cmd.err = parseClusterNodes(string(b))
+	return cmd.err
+}
+
+//------------------------------------------------------------------------------
+
+// GeoLocation is used with GeoAdd to add geospatial location.
+type GeoLocation struct {
+	Name                      string
+	Longitude, Latitude, Dist float64
+	GeoHash                   int64
+}
+
+// GeoRadiusQuery is used with GeoRadius to query geospatial index.
+type GeoRadiusQuery struct {
+	Radius float64
+	// Can be m, km, ft, or mi. Default is km.
+	Unit        string
+	WithCoord   bool
+	WithDist    bool
+	WithGeoHash bool
+	Count       int
+	// Can be ASC or DESC. Default is no sort order.
+	Sort      string
+	Store     string
+	StoreDist string
+}
+
+type GeoLocationCmd struct {
+	baseCmd
+
+	q         *GeoRadiusQuery
+	locations []GeoLocation
+}
+
+var _ Cmder = (*GeoLocationCmd)(nil)
+
+func NewGeoLocationCmd(q *GeoRadiusQuery, args ...interface{}) *GeoLocationCmd {
+	args = append(args, q.Radius)
+	if q.Unit != "" {
+		args = append(args, q.Unit)
+	} else {
+		args = append(args, "km")
+	}
+	if q.WithCoord {
+		args = append(args, "withcoord")
+	}
+	if q.WithDist {
+		args = append(args, "withdist")
+	}
+	if q.WithGeoHash {
+		args = append(args, "withhash")
+	}
+	if q.Count > 0 {
+		args = append(args, "count", q.Count)
+	}
+	if q.Sort != "" {
+		args = append(args, q.Sort)
+	}
+	if q.Store != "" {
+		args = append(args, "store")
+		args = append(args, q.Store)
+	}
+	if q.StoreDist != "" {
+		args = append(args, "storedist")
+		args = append(args, q.StoreDist)
+	}
+	return &GeoLocationCmd{
+		baseCmd: baseCmd{_args: args},
+		q:       q,
+	}
+}
+
+func (cmd *GeoLocationCmd) Val() []GeoLocation {
+	return cmd.locations
+}
+
+func (cmd *GeoLocationCmd) Result() ([]GeoLocation, error) {
+	return cmd.locations, cmd.err
+}
+
+func (cmd *GeoLocationCmd) String() string {
+	return cmdString(cmd, cmd.locations)
+}
+
+func (cmd *GeoLocationCmd) readReply(cn *pool.Conn) error {
+	var v interface{}
+	v, cmd.err = cn.Rd.ReadArrayReply(newGeoLocationSliceParser(cmd.q))
+	if cmd.err != nil {
+		return cmd.err
+	}
+	cmd.locations = v.([]GeoLocation)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// GeoSearchQuery is used with GeoSearch and GeoSearchStore to query
+// geospatial index. Center is either Member or Longitude and Latitude,
+// area is either Radius or BoxWidth and BoxHeight.
+type GeoSearchQuery struct {
+	// Member is used as the center when set, otherwise Longitude
+	// and Latitude are used.
+	Member              string
+	Longitude, Latitude float64
+
+	// Radius is used when set, otherwise the box is used.
+	Radius              float64
+	BoxWidth, BoxHeight float64
+	// Can be m, km, ft, or mi. Default is km.
+	Unit string
+
+	// Can be ASC or DESC. Default is no sort order.
+	Sort  string
+	Count int
+	// CountAny returns as soon as Count matches are found,
+	// so results may be not the closest ones.
+	CountAny bool
+
+	WithCoord   bool
+	WithDist    bool
+	WithGeoHash bool
+}
+
+func (q *GeoSearchQuery) appendArgs(args []interface{}) []interface{} {
+	if q.Member != "" {
+		args = append(args, "frommember", q.Member)
+	} else {
+		args = append(args, "fromlonlat", q.Longitude, q.Latitude)
+	}
+
+	if q.Radius > 0 {
+		args = append(args, "byradius", q.Radius)
+	} else {
+		args = append(args, "bybox", q.BoxWidth, q.BoxHeight)
+	}
+	if q.Unit != "" {
+		args = append(args, q.Unit)
+	} else {
+		args = append(args, "km")
+	}
+
+	if q.Sort != "" {
+		args = append(args, q.Sort)
+	}
+	if q.Count > 0 {
+		args = append(args, "count", q.Count)
+		if q.CountAny {
+			args = append(args, "any")
+		}
+	}
+	return args
+}
+
+// NewGeoSearchCmd returns GeoLocationCmd for GEOSEARCH command,
+// replies are parsed the same way as GEORADIUS replies.
+func NewGeoSearchCmd(q *GeoSearchQuery, args ...interface{}) *GeoLocationCmd {
+	args = q.appendArgs(args)
+	if q.WithCoord {
+		args = append(args, "withcoord")
+	}
+	if q.WithDist {
+		args = append(args, "withdist")
+	}
+	if q.WithGeoHash {
+		args = append(args, "withhash")
+	}
+	return &GeoLocationCmd{
+		baseCmd: baseCmd{_args: args},
+		q: &GeoRadiusQuery{
+			WithCoord:   q.WithCoord,
+			WithDist:    q.WithDist,
+			WithGeoHash: q.WithGeoHash,
+		},
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type GeoPos struct {
+	Longitude, Latitude float64
+}
+
+type GeoPosCmd struct {
+	baseCmd
+
+	positions []*GeoPos
+}
+
+var _ Cmder = (*GeoPosCmd)(nil)
+
+func NewGeoPosCmd(args ...interface{}) *GeoPosCmd {
+	return &GeoPosCmd{
+		baseCmd: baseCmd{_args: args},
+	}
+}
+
+func (cmd *GeoPosCmd) Val() []*GeoPos {
+	return cmd.positions
+}
+
+func (cmd *GeoPosCmd) Result() ([]*GeoPos, error) {
+	return cmd.Val(), cmd.Err()
+}
+
+func (cmd *GeoPosCmd) String() string {
+	return cmdString(cmd, cmd.positions)
+}
+
+func (cmd *GeoPosCmd) readReply(cn *pool.Conn) error {
+	var v interface{}
+	v, cmd.err = cn.Rd.ReadArrayReply(geoPosSliceParser)
+	if cmd.err != nil {
+		return cmd.err
+	}
+	cmd.positions = v.([]*GeoPos)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// ACLUser is the reply of ACL GETUSER command.
+type ACLUser struct {
+	Flags []string
+	// Hashes of the user passwords.
+	Passwords []string
+	// Commands rules, e.g. "+@all -debug".
+	Commands string
+	// Key patterns the user can access. Newer Redis versions
+	// report them with the ~ prefix, e.g. "~cached:*".
+	Keys []string
+	// Pub/Sub channel patterns the user can access.
+	// Requires Redis 6.2.
+	Channels []string
+}
+
+type ACLUserCmd struct {
+	baseCmd
+
+	val ACLUser
+}
+
+var _ Cmder = (*ACLUserCmd)(nil)
+
+func NewACLUserCmd(args ...interface{}) *ACLUserCmd {
+	return &ACLUserCmd{
+		baseCmd: baseCmd{_args: args},
+	}
+}
+
+func (cmd *ACLUserCmd) Val() ACLUser {
+	return cmd.val
+}
+
+func (cmd *ACLUserCmd) Result() (ACLUser, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *ACLUserCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *ACLUserCmd) readReply(cn *pool.Conn) error {
+	var v interface{}
+	v, cmd.err = cn.Rd.ReadArrayReply(aclUserParser)
+	if cmd.err != nil {
+		return cmd.err
+	}
+	cmd.val = v.(ACLUser)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// ACLLogEntry is a security event reported by ACL LOG command.
+type ACLLogEntry struct {
+	// Number of events logged within 60 seconds.
+	Count int64
+	// Can be command, key, channel or auth.
+	Reason string
+	// Can be toplevel, multi, lua or module.
+	Context  string
+	Object   string
+	Username string
+	Age      time.Duration
+	// Client that caused the event in CLIENT LIST format.
+	ClientInfo string
+}
+
+type ACLLogCmd struct {
+	baseCmd
+
+	val []ACLLogEntry
+}
+
+var _ Cmder = (*ACLLogCmd)(nil)
+
+func NewACLLogCmd(args ...interface{}) *ACLLogCmd {
+	return &ACLLogCmd{
+		baseCmd: baseCmd{_args: args},
+	}
+}
+
+func (cmd *ACLLogCmd) Val() []ACLLogEntry {
+	return cmd.val
+}
+
+func (cmd *ACLLogCmd) Result() ([]ACLLogEntry, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *ACLLogCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *ACLLogCmd) readReply(cn *pool.Conn) error {
+	var v interface{}
+	v, cmd.err = cn.Rd.ReadArrayReply(aclLogParser)
+	if cmd.err != nil {
+		return cmd.err
+	}
+	cmd.val = v.([]ACLLogEntry)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// SlowLog is a command reported by SLOWLOG GET command.
+type SlowLog struct {
+	ID int64
+	// Time when the command was run.
+	Time time.Time
+	// Time spent executing the command.
+	Duration time.Duration
+	Args     []string
+	// Client address and name, empty before Redis 4.0.
+	ClientAddr string
+	ClientName string
+}
+
+type SlowLogCmd struct {
+	baseCmd
+
+	val []SlowLog
+}
+
+var _ Cmder = (*SlowLogCmd)(nil)
+
+func NewSlowLogCmd(args ...interface{}) *SlowLogCmd {
+	return &SlowLogCmd{
+		baseCmd: baseCmd{_args: args},
+	}
+}
+
+func (cmd *SlowLogCmd) Val() []SlowLog {
+	return cmd.val
+}
+
+func (cmd *SlowLogCmd) Result() ([]SlowLog, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *SlowLogCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *SlowLogCmd) readReply(cn *pool.Conn) error {
+	var v interface{}
+	v, cmd.err = cn.Rd.ReadArrayReply(slowLogParser)
+	if cmd.err != nil {
+		return cmd.err
+	}
+	cmd.val = v.([]SlowLog)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// SlowLogMapCmd is used for SLOWLOG GET sent to every cluster node by
+// ClusterClient.SlowLogMap, replies are keyed by node address.
+type SlowLogMapCmd struct {
+	baseCmd
+
+	val map[string][]SlowLog
+}
+
+var _ Cmder = (*SlowLogMapCmd)(nil)
+
+func NewSlowLogMapCmd(args ...interface{}) *SlowLogMapCmd {
+	return &SlowLogMapCmd{
+		baseCmd: baseCmd{_args: args},
+	}
+}
+
+func (cmd *SlowLogMapCmd) Val() map[string][]SlowLog {
+	return cmd.val
+}
+
+func (cmd *SlowLogMapCmd) Result() (map[string][]SlowLog, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *SlowLogMapCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *SlowLogMapCmd) readReply(cn *pool.Conn) error {
+	_, cmd.err = cn.Rd.ReadArrayReply(slowLogParser)
+	if cmd.err != nil {
+		return cmd.err
+	}
+	// Node address is known only to ClusterClient.
+	cmd.err = errNodeMapCmd
+	return cmd.err
+}
+
+//------------------------------------------------------------------------------
+
+// LatencyEvent is an event reported by LATENCY LATEST command.
+type LatencyEvent struct {
+	Name string
+	// Time of the latest latency spike.
+	Time   time.Time
+	Latest time.Duration
+	Max    time.Duration
+}
+
+type LatencyLatestCmd struct {
+	baseCmd
+
+	val []LatencyEvent
+}
+
+var _ Cmder = (*LatencyLatestCmd)(nil)
+
+func NewLatencyLatestCmd(args ...interface{}) *LatencyLatestCmd {
+	return &LatencyLatestCmd{
+		baseCmd: baseCmd{_args: args},
+	}
+}
+
+func (cmd *LatencyLatestCmd) Val() []LatencyEvent {
+	return cmd.val
+}
+
+func (cmd *LatencyLatestCmd) Result() ([]LatencyEvent, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *LatencyLatestCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *LatencyLatestCmd) readReply(cn *pool.Conn) error {
+	var v interface{}
+	v, cmd.err = cn.Rd.ReadArrayReply(latencyLatestParser)
+	if cmd.err != nil {
+		return cmd.err
+	}
+	cmd.val = v.([]LatencyEvent)
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// LatencySample is a latency spike reported by LATENCY HISTORY command.
+type LatencySample struct {
+	Time    time.Time
+	Latency time.Duration
+}
+
+type LatencyHistoryCmd struct {
+	baseCmd
+
+	val []LatencySample
+}
+
+var _ Cmder = (*LatencyHistoryCmd)(nil)
+
+func NewLatencyHistoryCmd(args ...interface{}) *LatencyHistoryCmd {
+	return &LatencyHistoryCmd{
+		baseCmd: baseCmd{_args: args},
+	}
+}
+
+func (cmd *LatencyHistoryCmd) Val() []LatencySample {
+	return cmd.val
+}
+
+func (cmd *LatencyHistoryCmd) Result() ([]LatencySample, error) {
+	return cmd.val, cmd.err
+}
+
+func (cmd *LatencyHistoryCmd) String() string {
+	return cmdString(cmd, cmd.val)
+}
+
+func (cmd *LatencyHistoryCmd) readReply(cn *pool.Conn) error {
+	var v interface{}
+	v, cmd.err = cn.Rd.ReadArrayReply(latencyHistoryParser)
+	if cmd.err != nil {
+		return cmd.err
+	}
+	cmd.val = v.([]LatencySample)
 	return nil
 }
 
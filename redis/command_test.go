@@ -1,6 +1,10 @@
 package redis_test
 
 import (
+	"reflect"
+	"testing"
+	"time"
+
 	"github.com/go-redis/redis"
 
 	. "github.com/onsi/ginkgo"
@@ -58,3 +62,80 @@ var _ = Describe("Cmd", func() {
 	})
 
 })
+
+func TestParseClientInfo(t *testing.T) {
+	cases := []struct {
+		line string
+		info *redis.ClientInfo
+		err  string
+	}{
+		{
+			"id=3 addr=127.0.0.1:51234 laddr=127.0.0.1:6379 fd=8 name=worker age=12 idle=1 " +
+				"flags=N db=2 sub=1 psub=2 ssub=3 multi=-1 qbuf=26 qbuf-free=20448 argv-mem=10 " +
+				"obl=4 oll=5 omem=6 tot-mem=61466 events=r cmd=client|info user=default redir=-1 resp=2",
+			&redis.ClientInfo{
+				ID:            3,
+				Addr:          "127.0.0.1:51234",
+				LAddr:         "127.0.0.1:6379",
+				FD:            8,
+				Name:          "worker",
+				Age:           12 * time.Second,
+				Idle:          time.Second,
+				Flags:         "N",
+				DB:            2,
+				Sub:           1,
+				PSub:          2,
+				SSub:          3,
+				Multi:         -1,
+				QueryBuf:      26,
+				QueryBufFree:  20448,
+				OutputBuf:     4,
+				OutputListLen: 5,
+				OutputMemory:  6,
+				TotalMemory:   61466,
+				Events:        "r",
+				LastCmd:       "client|info",
+				User:          "default",
+				Redir:         -1,
+				Resp:          2,
+			},
+			"",
+		},
+		{
+			"id=7 addr=127.0.0.1:51235 name= cmd=NULL\n",
+			&redis.ClientInfo{
+				ID:      7,
+				Addr:    "127.0.0.1:51235",
+				LastCmd: "NULL",
+			},
+			"",
+		},
+		{
+			"id=x",
+			nil,
+			`redis: invalid client info field: "id=x"`,
+		},
+		{
+			"id=1 garbage",
+			nil,
+			`redis: invalid client info field: "garbage"`,
+		},
+	}
+
+	for _, c := range cases {
+		info, err := redis.ParseClientInfo(c.line)
+		if c.err != "" {
+			if err == nil || err.Error() != c.err {
+				t.Errorf("%q: got error %v, expected %q", c.line, err, c.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", c.line, err)
+			continue
+		}
+		if !reflect.DeepEqual(info, c.info) {
+			t.Errorf("%q: got %+v, expected %+v", c.line, info, c.info)
+		}
+	}
+}
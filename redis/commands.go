@@ -3,6 +3,7 @@ package redis
 import (
 	"errors"
 	"io"
+	"strconv"
 	"time"
 
 	"github.com/go-redis/redis/internal"
@@ -63,17 +64,22 @@ type Cmdable interface {
 	TxPipeline() Pipeliner
 
 	ClientGetName() *StringCmd
+	ClientID() *IntCmd
+	ClientInfo() *ClientInfoCmd
 	Echo(message interface{}) *StringCmd
 	Ping() *StatusCmd
 	Quit() *StatusCmd
 	Del(keys ...string) *IntCmd
 	Unlink(keys ...string) *IntCmd
 	Dump(key string) *StringCmd
+	Copy(source, destination string, replace bool) *IntCmd
+	CopyToDB(source, destination string, db int, replace bool) *IntCmd
 	Exists(keys ...string) *IntCmd
 	Expire(key string, expiration time.Duration) *BoolCmd
 	ExpireAt(key string, tm time.Time) *BoolCmd
 	Keys(pattern string) *StringSliceCmd
 	Migrate(host, port, key string, db int64, timeout time.Duration) *StatusCmd
+	MigrateKeys(host, port string, keys []string, db int64, timeout time.Duration, a MigrateArgs) *StatusCmd
 	Move(key string, db int64) *BoolCmd
 	ObjectRefCount(key string) *IntCmd
 	ObjectEncoding(key string) *StringCmd
@@ -104,12 +110,17 @@ type Cmdable interface {
 	BitOpXor(destKey string, keys ...string) *IntCmd
 	BitOpNot(destKey string, key string) *IntCmd
 	BitPos(key string, bit int64, pos ...int64) *IntCmd
+	BitField(key string, args *BitFieldArgs) *IntSliceCmd
+	BitFieldRO(key string, args *BitFieldArgs) *IntSliceCmd
 	Decr(key string) *IntCmd
 	DecrBy(key string, decrement int64) *IntCmd
 	Get(key string) *StringCmd
 	GetBit(key string, offset int64) *IntCmd
 	GetRange(key string, start, end int64) *StringCmd
 	GetSet(key string, value interface{}) *StringCmd
+	GetEx(key string, expiration time.Duration) *StringCmd
+	GetExAt(key string, tm time.Time) *StringCmd
+	GetDel(key string) *StringCmd
 	Incr(key string) *IntCmd
 	IncrBy(key string, value int64) *IntCmd
 	IncrByFloat(key string, value float64) *FloatCmd
@@ -120,8 +131,12 @@ type Cmdable interface {
 	SetBit(key string, offset int64, value int) *IntCmd
 	SetNX(key string, value interface{}, expiration time.Duration) *BoolCmd
 	SetXX(key string, value interface{}, expiration time.Duration) *BoolCmd
+	SetArgs(key string, value interface{}, a SetArgs) *StatusCmd
 	SetRange(key string, offset int64, value string) *IntCmd
 	StrLen(key string) *IntCmd
+	LCS(key1, key2 string) *StringCmd
+	LCSLen(key1, key2 string) *IntCmd
+	LCSIdx(key1, key2 string, minMatchLen int64) *LCSIdxCmd
 	HDel(key string, fields ...string) *IntCmd
 	HExists(key, field string) *BoolCmd
 	HGet(key, field string) *StringCmd
@@ -138,12 +153,19 @@ type Cmdable interface {
 	BLPop(timeout time.Duration, keys ...string) *StringSliceCmd
 	BRPop(timeout time.Duration, keys ...string) *StringSliceCmd
 	BRPopLPush(source, destination string, timeout time.Duration) *StringCmd
+	BLMove(source, destination string, srcpos, destpos ListDirection, timeout time.Duration) *StringCmd
+	BLMPop(timeout time.Duration, direction ListDirection, count int64, keys ...string) *KeyValuesCmd
 	LIndex(key string, index int64) *StringCmd
 	LInsert(key, op string, pivot, value interface{}) *IntCmd
 	LInsertBefore(key string, pivot, value interface{}) *IntCmd
 	LInsertAfter(key string, pivot, value interface{}) *IntCmd
 	LLen(key string) *IntCmd
+	LMove(source, destination string, srcpos, destpos ListDirection) *StringCmd
+	LMPop(direction ListDirection, count int64, keys ...string) *KeyValuesCmd
 	LPop(key string) *StringCmd
+	LPopCount(key string, count int) *StringSliceCmd
+	LPos(key string, value interface{}, a LPosArgs) *IntCmd
+	LPosCount(key string, value interface{}, count int64, a LPosArgs) *IntSliceCmd
 	LPush(key string, values ...interface{}) *IntCmd
 	LPushX(key string, value interface{}) *IntCmd
 	LRange(key string, start, stop int64) *StringSliceCmd
@@ -151,6 +173,7 @@ type Cmdable interface {
 	LSet(key string, index int64, value interface{}) *StatusCmd
 	LTrim(key string, start, stop int64) *StatusCmd
 	RPop(key string) *StringCmd
+	RPopCount(key string, count int) *StringSliceCmd
 	RPopLPush(source, destination string) *StringCmd
 	RPush(key string, values ...interface{}) *IntCmd
 	RPushX(key string, value interface{}) *IntCmd
@@ -159,8 +182,10 @@ type Cmdable interface {
 	SDiff(keys ...string) *StringSliceCmd
 	SDiffStore(destination string, keys ...string) *IntCmd
 	SInter(keys ...string) *StringSliceCmd
+	SInterCard(limit int64, keys ...string) *IntCmd
 	SInterStore(destination string, keys ...string) *IntCmd
 	SIsMember(key string, member interface{}) *BoolCmd
+	SMIsMember(key string, members ...interface{}) *BoolSliceCmd
 	SMembers(key string) *StringSliceCmd
 	SMembersMap(key string) *StringStructMapCmd
 	SMove(source, destination string, member interface{}) *BoolCmd
@@ -203,14 +228,33 @@ type Cmdable interface {
 	ZRevRank(key, member string) *IntCmd
 	ZScore(key, member string) *FloatCmd
 	ZUnionStore(dest string, store ZStore, keys ...string) *IntCmd
+	ZPopMin(key string, count ...int64) *ZSliceCmd
+	ZPopMax(key string, count ...int64) *ZSliceCmd
+	BZPopMin(timeout time.Duration, keys ...string) *ZWithKeyCmd
+	BZPopMax(timeout time.Duration, keys ...string) *ZWithKeyCmd
+	ZMScore(key string, members ...string) *FloatSliceCmd
+	ZRandMember(key string, count int64) *StringSliceCmd
+	ZRandMemberWithScores(key string, count int64) *ZSliceCmd
+	ZUnion(store ZStore, keys ...string) *StringSliceCmd
+	ZUnionWithScores(store ZStore, keys ...string) *ZSliceCmd
+	ZInter(store ZStore, keys ...string) *StringSliceCmd
+	ZInterWithScores(store ZStore, keys ...string) *ZSliceCmd
+	ZDiff(keys ...string) *StringSliceCmd
+	ZDiffWithScores(keys ...string) *ZSliceCmd
+	ZDiffStore(destination string, keys ...string) *IntCmd
+	ZRangeStore(dst, src string, opt ZRangeArgs) *IntCmd
 	PFAdd(key string, els ...interface{}) *IntCmd
 	PFCount(keys ...string) *IntCmd
 	PFMerge(dest string, keys ...string) *StatusCmd
 	BgRewriteAOF() *StatusCmd
 	BgSave() *StatusCmd
 	ClientKill(ipPort string) *StatusCmd
+	ClientKillByFilter(filter ClientKillFilter) *IntCmd
 	ClientList() *StringCmd
+	ClientListInfo() *ClientInfoSliceCmd
 	ClientPause(dur time.Duration) *BoolCmd
+	ClientUnblock(id int64) *IntCmd
+	ClientUnblockWithError(id int64) *IntCmd
 	ConfigGet(parameter string) *SliceCmd
 	ConfigResetStat() *StatusCmd
 	ConfigSet(parameter, value string) *StatusCmd
@@ -221,6 +265,7 @@ type Cmdable interface {
 	FlushDB() *StatusCmd
 	FlushDBAsync() *StatusCmd
 	Info(section ...string) *StringCmd
+	InfoSections(section ...string) *InfoCmd
 	LastSave() *IntCmd
 	Save() *StatusCmd
 	Shutdown() *StatusCmd
@@ -228,6 +273,24 @@ type Cmdable interface {
 	ShutdownNoSave() *StatusCmd
 	SlaveOf(host, port string) *StatusCmd
 	Time() *TimeCmd
+	SlowLogGet(num int64) *SlowLogCmd
+	SlowLogLen() *IntCmd
+	SlowLogReset() *StatusCmd
+	LatencyLatest() *LatencyLatestCmd
+	LatencyHistory(event string) *LatencyHistoryCmd
+	LatencyReset(events ...string) *IntCmd
+	LatencyDoctor() *StringCmd
+	ACLSetUser(username string, rules ...string) *StatusCmd
+	ACLGetUser(username string) *ACLUserCmd
+	ACLDelUser(usernames ...string) *IntCmd
+	ACLList() *StringSliceCmd
+	ACLUsers() *StringSliceCmd
+	ACLWhoAmI() *StringCmd
+	ACLCat() *StringSliceCmd
+	ACLCatCategory(category string) *StringSliceCmd
+	ACLLog(count int64) *ACLLogCmd
+	ACLLogReset() *StatusCmd
+	ACLGenPass(bits int) *StringCmd
 	Eval(script string, keys []string, args ...interface{}) *Cmd
 	EvalSha(sha1 string, keys []string, args ...interface{}) *Cmd
 	ScriptExists(hashes ...string) *BoolSliceCmd
@@ -236,11 +299,13 @@ type Cmdable interface {
 	ScriptLoad(script string) *StringCmd
 	DebugObject(key string) *StringCmd
 	Publish(channel string, message interface{}) *IntCmd
+	SPublish(channel string, message interface{}) *IntCmd
 	PubSubChannels(pattern string) *StringSliceCmd
 	PubSubNumSub(channels ...string) *StringIntMapCmd
 	PubSubNumPat() *IntCmd
 	ClusterSlots() *ClusterSlotsCmd
 	ClusterNodes() *StringCmd
+	ClusterNodesInfo() *ClusterNodesCmd
 	ClusterMeet(host, port string) *StatusCmd
 	ClusterForget(nodeID string) *StatusCmd
 	ClusterReplicate(nodeID string) *StatusCmd
@@ -257,12 +322,21 @@ type Cmdable interface {
 	ClusterFailover() *StatusCmd
 	ClusterAddSlots(slots ...int) *StatusCmd
 	ClusterAddSlotsRange(min, max int) *StatusCmd
+	ClusterSetSlotImporting(slot int, nodeID string) *StatusCmd
+	ClusterSetSlotMigrating(slot int, nodeID string) *StatusCmd
+	ClusterSetSlotNode(slot int, nodeID string) *StatusCmd
+	ClusterSetSlotStable(slot int) *StatusCmd
+	ClusterGetKeysInSlot(slot int, count int) *StringSliceCmd
 	GeoAdd(key string, geoLocation ...*GeoLocation) *IntCmd
 	GeoPos(key string, members ...string) *GeoPosCmd
 	GeoRadius(key string, longitude, latitude float64, query *GeoRadiusQuery) *GeoLocationCmd
 	GeoRadiusRO(key string, longitude, latitude float64, query *GeoRadiusQuery) *GeoLocationCmd
 	GeoRadiusByMember(key, member string, query *GeoRadiusQuery) *GeoLocationCmd
 	GeoRadiusByMemberRO(key, member string, query *GeoRadiusQuery) *GeoLocationCmd
+	GeoRadiusStore(key string, longitude, latitude float64, query *GeoRadiusQuery) *IntCmd
+	GeoRadiusByMemberStore(key, member string, query *GeoRadiusQuery) *IntCmd
+	GeoSearch(key string, query *GeoSearchQuery) *GeoLocationCmd
+	GeoSearchStore(key, store string, query *GeoSearchQuery, storeDist bool) *IntCmd
 	GeoDist(key string, member1, member2, unit string) *FloatCmd
 	GeoHash(key string, members ...string) *StringSliceCmd
 	Command() *CommandsInfoCmd
@@ -271,9 +345,11 @@ type Cmdable interface {
 type StatefulCmdable interface {
 	Cmdable
 	Auth(password string) *StatusCmd
+	AuthACL(username, password string) *StatusCmd
 	Select(index int) *StatusCmd
 	SwapDB(index1, index2 int) *StatusCmd
 	ClientSetName(name string) *BoolCmd
+	ClientNoEvict(on bool) *StatusCmd
 	ReadOnly() *StatusCmd
 	ReadWrite() *StatusCmd
 }
@@ -309,6 +385,14 @@ func (c *statefulCmdable) Auth(password string) *StatusCmd {
 	return cmd
 }
 
+// AuthACL authenticates the connection as the ACL user.
+// Requires Redis 6.0.
+func (c *statefulCmdable) AuthACL(username, password string) *StatusCmd {
+	cmd := NewStatusCmd("auth", username, password)
+	c.process(cmd)
+	return cmd
+}
+
 func (c *cmdable) Echo(message interface{}) *StringCmd {
 	cmd := NewStringCmd("echo", message)
 	c.process(cmd)
@@ -373,6 +457,32 @@ func (c *cmdable) Dump(key string) *StringCmd {
 	return cmd
 }
 
+// Copy copies the value of the source key to the destination key
+// in the current database and returns 1 if the key was copied.
+// Redis `COPY source destination [REPLACE]` command, requires Redis 6.2.
+func (c *cmdable) Copy(source, destination string, replace bool) *IntCmd {
+	args := []interface{}{"copy", source, destination}
+	if replace {
+		args = append(args, "replace")
+	}
+	cmd := NewIntCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+// CopyToDB is like Copy, but the destination key is created
+// in the db database.
+// Redis `COPY source destination DB db [REPLACE]` command.
+func (c *cmdable) CopyToDB(source, destination string, db int, replace bool) *IntCmd {
+	args := []interface{}{"copy", source, destination, "db", db}
+	if replace {
+		args = append(args, "replace")
+	}
+	cmd := NewIntCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
 func (c *cmdable) Exists(keys ...string) *IntCmd {
 	args := make([]interface{}, 1+len(keys))
 	args[0] = "exists"
@@ -416,6 +526,50 @@ func (c *cmdable) Migrate(host, port, key string, db int64, timeout time.Duratio
 	return cmd
 }
 
+// MigrateArgs provides optional arguments for the MigrateKeys function.
+type MigrateArgs struct {
+	// Copy keeps the keys on the source instance.
+	Copy bool
+	// Replace overwrites existing keys on the target instance.
+	Replace bool
+
+	// Password is used to authenticate on the target instance.
+	// With Username AUTH2 is sent instead of AUTH (Redis >= 6.0).
+	Username string
+	Password string
+}
+
+// MigrateKeys atomically transfers multiple keys in one MIGRATE call.
+// Status is NOKEY if none of the keys exist on the source instance.
+func (c *cmdable) MigrateKeys(host, port string, keys []string, db int64, timeout time.Duration, a MigrateArgs) *StatusCmd {
+	args := make([]interface{}, 6, 12+len(keys))
+	args[0] = "migrate"
+	args[1] = host
+	args[2] = port
+	args[3] = ""
+	args[4] = db
+	args[5] = formatMs(timeout)
+	if a.Copy {
+		args = append(args, "copy")
+	}
+	if a.Replace {
+		args = append(args, "replace")
+	}
+	if a.Username != "" {
+		args = append(args, "auth2", a.Username, a.Password)
+	} else if a.Password != "" {
+		args = append(args, "auth", a.Password)
+	}
+	args = append(args, "keys")
+	for _, key := range keys {
+		args = append(args, key)
+	}
+	cmd := NewStatusCmd(args...)
+	cmd.setReadTimeout(readTimeout(timeout))
+	c.process(cmd)
+	return cmd
+}
+
 func (c *cmdable) Move(key string, db int64) *BoolCmd {
 	cmd := NewBoolCmd("move", key, db)
 	c.process(cmd)
@@ -709,6 +863,98 @@ func (c *cmdable) BitPos(key string, bit int64, pos ...int64) *IntCmd {
 	return cmd
 }
 
+// BitFieldType is an integer type of a BITFIELD field, e.g. i8 or u16.
+type BitFieldType string
+
+// BitFieldSigned returns the type of signed integers with the given width.
+func BitFieldSigned(bits int) BitFieldType {
+	return BitFieldType("i" + strconv.Itoa(bits))
+}
+
+// BitFieldUnsigned returns the type of unsigned integers with the given width.
+func BitFieldUnsigned(bits int) BitFieldType {
+	return BitFieldType("u" + strconv.Itoa(bits))
+}
+
+// BitFieldOffset is a bit offset of a BITFIELD field.
+type BitFieldOffset string
+
+// BitFieldBit returns the offset of the field that starts at the bit.
+func BitFieldBit(offset int64) BitFieldOffset {
+	return BitFieldOffset(strconv.FormatInt(offset, 10))
+}
+
+// BitFieldIndex returns the offset of the index-th field of the type
+// width, i.e. the "#index" form.
+func BitFieldIndex(index int64) BitFieldOffset {
+	return BitFieldOffset("#" + strconv.FormatInt(index, 10))
+}
+
+// BitFieldOverflow controls SET and INCRBY behavior on overflow.
+type BitFieldOverflow string
+
+const (
+	BitFieldWrap BitFieldOverflow = "wrap"
+	BitFieldSat  BitFieldOverflow = "sat"
+	// BitFieldFail leaves the field unchanged and replies with nil.
+	BitFieldFail BitFieldOverflow = "fail"
+)
+
+// BitFieldArgs builds BITFIELD subcommands, e.g.
+//
+//	new(BitFieldArgs).
+//		Overflow(BitFieldFail).
+//		IncrBy(BitFieldUnsigned(8), BitFieldIndex(1), 1).
+//		Get(BitFieldUnsigned(8), BitFieldIndex(0))
+type BitFieldArgs struct {
+	args []interface{}
+}
+
+func (a *BitFieldArgs) Get(typ BitFieldType, offset BitFieldOffset) *BitFieldArgs {
+	a.args = append(a.args, "get", string(typ), string(offset))
+	return a
+}
+
+func (a *BitFieldArgs) Set(typ BitFieldType, offset BitFieldOffset, value int64) *BitFieldArgs {
+	a.args = append(a.args, "set", string(typ), string(offset), value)
+	return a
+}
+
+func (a *BitFieldArgs) IncrBy(typ BitFieldType, offset BitFieldOffset, increment int64) *BitFieldArgs {
+	a.args = append(a.args, "incrby", string(typ), string(offset), increment)
+	return a
+}
+
+// Overflow changes overflow behavior of the following SET and INCRBY
+// subcommands. Default is BitFieldWrap.
+func (a *BitFieldArgs) Overflow(overflow BitFieldOverflow) *BitFieldArgs {
+	a.args = append(a.args, "overflow", string(overflow))
+	return a
+}
+
+func (c *cmdable) bitField(name, key string, a *BitFieldArgs) *IntSliceCmd {
+	args := []interface{}{name, key}
+	if a != nil {
+		args = append(args, a.args...)
+	}
+	cmd := NewIntSliceCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+// BitField replies with a value for every GET, SET and INCRBY
+// subcommand. Replies of SET and INCRBY that failed with
+// OVERFLOW FAIL are nil, see IntSliceCmd.Nil.
+func (c *cmdable) BitField(key string, args *BitFieldArgs) *IntSliceCmd {
+	return c.bitField("bitfield", key, args)
+}
+
+// BitFieldRO is a read-only variant of BitField that accepts only GET
+// subcommands and can be sent to replicas. Requires Redis 6.2.
+func (c *cmdable) BitFieldRO(key string, args *BitFieldArgs) *IntSliceCmd {
+	return c.bitField("bitfield_ro", key, args)
+}
+
 func (c *cmdable) Decr(key string) *IntCmd {
 	cmd := NewIntCmd("decr", key)
 	c.process(cmd)
@@ -746,6 +992,41 @@ func (c *cmdable) GetSet(key string, value interface{}) *StringCmd {
 	return cmd
 }
 
+// Redis `GETEX key [EX seconds|PX milliseconds|PERSIST]` command.
+// Zero expiration removes the key expiration time and negative
+// expiration leaves it unchanged. Requires Redis 6.2.
+func (c *cmdable) GetEx(key string, expiration time.Duration) *StringCmd {
+	args := []interface{}{"getex", key}
+	if expiration > 0 {
+		if usePrecise(expiration) {
+			args = append(args, "px", formatMs(expiration))
+		} else {
+			args = append(args, "ex", formatSec(expiration))
+		}
+	} else if expiration == 0 {
+		args = append(args, "persist")
+	}
+	cmd := NewStringCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+// Redis `GETEX key EXAT timestamp|PXAT timestamp` command.
+// Requires Redis 6.2.
+func (c *cmdable) GetExAt(key string, tm time.Time) *StringCmd {
+	args := append([]interface{}{"getex", key}, expireAtArgs(tm)...)
+	cmd := NewStringCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+// Redis `GETDEL key` command. Requires Redis 6.2.
+func (c *cmdable) GetDel(key string) *StringCmd {
+	cmd := NewStringCmd("getdel", key)
+	c.process(cmd)
+	return cmd
+}
+
 func (c *cmdable) Incr(key string) *IntCmd {
 	cmd := NewIntCmd("incr", key)
 	c.process(cmd)
@@ -862,6 +1143,62 @@ func (c *cmdable) SetXX(key string, value interface{}, expiration time.Duration)
 	return cmd
 }
 
+// SetArgs provides arguments for the SetArgs function.
+type SetArgs struct {
+	// Mode can be NX or XX or empty.
+	Mode string
+
+	// Zero TTL means the key has no expiration time.
+	TTL time.Duration
+	// ExpireAt sets the time at which the key expires
+	// and takes precedence over TTL.
+	ExpireAt time.Time
+	// KeepTTL retains the expiration time of the existing key.
+	KeepTTL bool
+
+	// Get makes the command reply with the old value of the key.
+	Get bool
+}
+
+// Redis `SET key value [NX|XX] [GET] [EX|PX|EXAT|PXAT|KEEPTTL]` command.
+//
+// Without Get the reply is OK. With Get the reply is the old value or
+// Nil error if the key did not exist. Nil error is also returned when
+// the key is not set because of NX or XX.
+func (c *cmdable) SetArgs(key string, value interface{}, a SetArgs) *StatusCmd {
+	args := []interface{}{"set", key, value}
+	switch {
+	case !a.ExpireAt.IsZero():
+		args = append(args, expireAtArgs(a.ExpireAt)...)
+	case a.TTL > 0:
+		if usePrecise(a.TTL) {
+			args = append(args, "px", formatMs(a.TTL))
+		} else {
+			args = append(args, "ex", formatSec(a.TTL))
+		}
+	case a.KeepTTL:
+		args = append(args, "keepttl")
+	}
+	if a.Mode != "" {
+		args = append(args, a.Mode)
+	}
+	if a.Get {
+		args = append(args, "get")
+	}
+	cmd := NewStatusCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+// expireAtArgs returns EXAT or PXAT arguments depending on precision
+// of the time.
+func expireAtArgs(tm time.Time) []interface{} {
+	if tm.Nanosecond()%int(time.Second) != 0 {
+		return []interface{}{"pxat", tm.UnixNano() / int64(time.Millisecond)}
+	}
+	return []interface{}{"exat", tm.Unix()}
+}
+
 func (c *cmdable) SetRange(key string, offset int64, value string) *IntCmd {
 	cmd := NewIntCmd("setrange", key, offset, value)
 	c.process(cmd)
@@ -874,6 +1211,35 @@ func (c *cmdable) StrLen(key string) *IntCmd {
 	return cmd
 }
 
+// Redis `LCS key1 key2` command returns the longest common subsequence
+// of the strings. Requires Redis 7.0.
+func (c *cmdable) LCS(key1, key2 string) *StringCmd {
+	cmd := NewStringCmd("lcs", key1, key2)
+	c.process(cmd)
+	return cmd
+}
+
+// Redis `LCS key1 key2 LEN` command returns the length
+// of the longest common subsequence.
+func (c *cmdable) LCSLen(key1, key2 string) *IntCmd {
+	cmd := NewIntCmd("lcs", key1, key2, "len")
+	c.process(cmd)
+	return cmd
+}
+
+// Redis `LCS key1 key2 IDX MINMATCHLEN len WITHMATCHLEN` command returns
+// positions of the matches that are at least minMatchLen long.
+func (c *cmdable) LCSIdx(key1, key2 string, minMatchLen int64) *LCSIdxCmd {
+	args := []interface{}{"lcs", key1, key2, "idx"}
+	if minMatchLen > 0 {
+		args = append(args, "minmatchlen", minMatchLen)
+	}
+	args = append(args, "withmatchlen")
+	cmd := NewLCSIdxCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
 //------------------------------------------------------------------------------
 
 func (c *cmdable) HDel(key string, fields ...string) *IntCmd {
@@ -1015,6 +1381,43 @@ func (c *cmdable) BRPopLPush(source, destination string, timeout time.Duration)
 	return cmd
 }
 
+// Redis `BLMOVE source destination LEFT|RIGHT LEFT|RIGHT timeout` command.
+// Requires Redis 6.2.
+func (c *cmdable) BLMove(
+	source, destination string, srcpos, destpos ListDirection, timeout time.Duration,
+) *StringCmd {
+	cmd := NewStringCmd(
+		"blmove",
+		source,
+		destination,
+		string(srcpos),
+		string(destpos),
+		formatSec(timeout),
+	)
+	cmd.setReadTimeout(readTimeout(timeout))
+	c.process(cmd)
+	return cmd
+}
+
+// Redis `BLMPOP timeout numkeys key [key ...] LEFT|RIGHT COUNT count`
+// command. Requires Redis 7.0.
+func (c *cmdable) BLMPop(
+	timeout time.Duration, direction ListDirection, count int64, keys ...string,
+) *KeyValuesCmd {
+	args := make([]interface{}, 3, 3+len(keys)+3)
+	args[0] = "blmpop"
+	args[1] = formatSec(timeout)
+	args[2] = len(keys)
+	for _, key := range keys {
+		args = append(args, key)
+	}
+	args = append(args, string(direction), "count", count)
+	cmd := NewKeyValuesCmd(args...)
+	cmd.setReadTimeout(readTimeout(timeout))
+	c.process(cmd)
+	return cmd
+}
+
 func (c *cmdable) LIndex(key string, index int64) *StringCmd {
 	cmd := NewStringCmd("lindex", key, index)
 	c.process(cmd)
@@ -1045,12 +1448,89 @@ func (c *cmdable) LLen(key string) *IntCmd {
 	return cmd
 }
 
+// ListDirection is the end of a list used by LMOVE and LMPOP commands.
+type ListDirection string
+
+const (
+	ListLeft  ListDirection = "left"
+	ListRight ListDirection = "right"
+)
+
+// Redis `LMOVE source destination LEFT|RIGHT LEFT|RIGHT` command.
+// Requires Redis 6.2.
+func (c *cmdable) LMove(source, destination string, srcpos, destpos ListDirection) *StringCmd {
+	cmd := NewStringCmd("lmove", source, destination, string(srcpos), string(destpos))
+	c.process(cmd)
+	return cmd
+}
+
+// Redis `LMPOP numkeys key [key ...] LEFT|RIGHT COUNT count` command
+// pops elements from the first non-empty list. Requires Redis 7.0.
+func (c *cmdable) LMPop(direction ListDirection, count int64, keys ...string) *KeyValuesCmd {
+	args := make([]interface{}, 2, 2+len(keys)+3)
+	args[0] = "lmpop"
+	args[1] = len(keys)
+	for _, key := range keys {
+		args = append(args, key)
+	}
+	args = append(args, string(direction), "count", count)
+	cmd := NewKeyValuesCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
 func (c *cmdable) LPop(key string) *StringCmd {
 	cmd := NewStringCmd("lpop", key)
 	c.process(cmd)
 	return cmd
 }
 
+// Redis `LPOP key count` command. Requires Redis 6.2.
+func (c *cmdable) LPopCount(key string, count int) *StringSliceCmd {
+	cmd := NewStringSliceCmd("lpop", key, count)
+	c.process(cmd)
+	return cmd
+}
+
+// LPosArgs provides optional arguments for LPos and LPosCount functions.
+type LPosArgs struct {
+	// Rank is the 1-based number of the match to return, negative
+	// rank searches from the tail of the list. Zero means the first match.
+	Rank int64
+	// MaxLen limits the number of compared elements, zero means no limit.
+	MaxLen int64
+}
+
+func (a *LPosArgs) appendArgs(args []interface{}) []interface{} {
+	if a.Rank != 0 {
+		args = append(args, "rank", a.Rank)
+	}
+	if a.MaxLen != 0 {
+		args = append(args, "maxlen", a.MaxLen)
+	}
+	return args
+}
+
+// Redis `LPOS key element [RANK rank] [MAXLEN len]` command returns
+// the index of the matching element or Nil error.
+// Requires Redis 6.0.6.
+func (c *cmdable) LPos(key string, value interface{}, a LPosArgs) *IntCmd {
+	args := a.appendArgs([]interface{}{"lpos", key, value})
+	cmd := NewIntCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+// Redis `LPOS key element COUNT count [RANK rank] [MAXLEN len]` command
+// returns indexes of at most count matching elements, zero count
+// returns all matches.
+func (c *cmdable) LPosCount(key string, value interface{}, count int64, a LPosArgs) *IntSliceCmd {
+	args := a.appendArgs([]interface{}{"lpos", key, value, "count", count})
+	cmd := NewIntSliceCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
 func (c *cmdable) LPush(key string, values ...interface{}) *IntCmd {
 	args := make([]interface{}, 2, 2+len(values))
 	args[0] = "lpush"
@@ -1107,6 +1587,13 @@ func (c *cmdable) RPop(key string) *StringCmd {
 	return cmd
 }
 
+// Redis `RPOP key count` command. Requires Redis 6.2.
+func (c *cmdable) RPopCount(key string, count int) *StringSliceCmd {
+	cmd := NewStringSliceCmd("rpop", key, count)
+	c.process(cmd)
+	return cmd
+}
+
 func (c *cmdable) RPopLPush(source, destination string) *StringCmd {
 	cmd := NewStringCmd("rpoplpush", source, destination)
 	c.process(cmd)
@@ -1181,6 +1668,24 @@ func (c *cmdable) SInter(keys ...string) *StringSliceCmd {
 	return cmd
 }
 
+// Redis `SINTERCARD numkeys key [key ...] [LIMIT limit]` command returns
+// the cardinality of the intersection. Zero limit means no limit.
+// Requires Redis 7.0.
+func (c *cmdable) SInterCard(limit int64, keys ...string) *IntCmd {
+	args := make([]interface{}, 2, 2+len(keys)+2)
+	args[0] = "sintercard"
+	args[1] = len(keys)
+	for _, key := range keys {
+		args = append(args, key)
+	}
+	if limit > 0 {
+		args = append(args, "limit", limit)
+	}
+	cmd := NewIntCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
 func (c *cmdable) SInterStore(destination string, keys ...string) *IntCmd {
 	args := make([]interface{}, 2+len(keys))
 	args[0] = "sinterstore"
@@ -1199,6 +1704,18 @@ func (c *cmdable) SIsMember(key string, member interface{}) *BoolCmd {
 	return cmd
 }
 
+// Redis `SMISMEMBER key member [member ...]` command.
+// Requires Redis 6.2.
+func (c *cmdable) SMIsMember(key string, members ...interface{}) *BoolSliceCmd {
+	args := make([]interface{}, 2, 2+len(members))
+	args[0] = "smismember"
+	args[1] = key
+	args = appendArgs(args, members)
+	cmd := NewBoolSliceCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
 // Redis `SMEMBERS key` command output as a slice
 func (c *cmdable) SMembers(key string) *StringSliceCmd {
 	cmd := NewStringSliceCmd("smembers", key)
@@ -1288,7 +1805,14 @@ type Z struct {
 	Member interface{}
 }
 
-// ZStore is used as an arg to ZInterStore and ZUnionStore.
+// ZWithKey represents sorted set member including the name of the key
+// where it was popped.
+type ZWithKey struct {
+	Z
+	Key string
+}
+
+// ZStore is used as an arg to ZInterStore, ZUnionStore, ZInter and ZUnion.
 type ZStore struct {
 	Weights []float64
 	// Can be SUM, MIN or MAX.
@@ -1411,13 +1935,11 @@ func (c *cmdable) ZIncrBy(key string, increment float64, member string) *FloatCm
 	return cmd
 }
 
-func (c *cmdable) ZInterStore(destination string, store ZStore, keys ...string) *IntCmd {
-	args := make([]interface{}, 3+len(keys))
-	args[0] = "zinterstore"
-	args[1] = destination
-	args[2] = len(keys)
-	for i, key := range keys {
-		args[3+i] = key
+// zStoreArgs appends numkeys, keys, weights and aggregate arguments.
+func zStoreArgs(args []interface{}, store ZStore, keys []string) []interface{} {
+	args = append(args, len(keys))
+	for _, key := range keys {
+		args = append(args, key)
 	}
 	if len(store.Weights) > 0 {
 		args = append(args, "weights")
@@ -1428,6 +1950,11 @@ func (c *cmdable) ZInterStore(destination string, store ZStore, keys ...string)
 	if store.Aggregate != "" {
 		args = append(args, "aggregate", store.Aggregate)
 	}
+	return args
+}
+
+func (c *cmdable) ZInterStore(destination string, store ZStore, keys ...string) *IntCmd {
+	args := zStoreArgs([]interface{}{"zinterstore", destination}, store, keys)
 	cmd := NewIntCmd(args...)
 	c.process(cmd)
 	return cmd
@@ -1606,21 +2133,171 @@ func (c *cmdable) ZScore(key, member string) *FloatCmd {
 }
 
 func (c *cmdable) ZUnionStore(dest string, store ZStore, keys ...string) *IntCmd {
-	args := make([]interface{}, 3+len(keys))
-	args[0] = "zunionstore"
-	args[1] = dest
-	args[2] = len(keys)
+	args := zStoreArgs([]interface{}{"zunionstore", dest}, store, keys)
+	cmd := NewIntCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+func (c *cmdable) zPop(zcmd, key string, count []int64) *ZSliceCmd {
+	args := []interface{}{zcmd, key}
+	switch len(count) {
+	case 0:
+	case 1:
+		args = append(args, count[0])
+	default:
+		panic("too many arguments")
+	}
+	cmd := NewZSliceCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+// Redis `ZPOPMIN key [count]` command.
+func (c *cmdable) ZPopMin(key string, count ...int64) *ZSliceCmd {
+	return c.zPop("zpopmin", key, count)
+}
+
+// Redis `ZPOPMAX key [count]` command.
+func (c *cmdable) ZPopMax(key string, count ...int64) *ZSliceCmd {
+	return c.zPop("zpopmax", key, count)
+}
+
+func (c *cmdable) bzPop(zcmd string, timeout time.Duration, keys []string) *ZWithKeyCmd {
+	args := make([]interface{}, 1+len(keys)+1)
+	args[0] = zcmd
 	for i, key := range keys {
-		args[3+i] = key
+		args[1+i] = key
 	}
-	if len(store.Weights) > 0 {
-		args = append(args, "weights")
-		for _, weight := range store.Weights {
-			args = append(args, weight)
-		}
+	args[len(args)-1] = formatSec(timeout)
+	cmd := NewZWithKeyCmd(args...)
+	cmd.setReadTimeout(readTimeout(timeout))
+	c.process(cmd)
+	return cmd
+}
+
+// Redis `BZPOPMIN key [key ...] timeout` command.
+// Returns Nil error when timeout is reached.
+func (c *cmdable) BZPopMin(timeout time.Duration, keys ...string) *ZWithKeyCmd {
+	return c.bzPop("bzpopmin", timeout, keys)
+}
+
+// Redis `BZPOPMAX key [key ...] timeout` command.
+// Returns Nil error when timeout is reached.
+func (c *cmdable) BZPopMax(timeout time.Duration, keys ...string) *ZWithKeyCmd {
+	return c.bzPop("bzpopmax", timeout, keys)
+}
+
+// Redis `ZMSCORE key member [member ...]` command. Scores of missing
+// members are reported by FloatSliceCmd.Nil.
+func (c *cmdable) ZMScore(key string, members ...string) *FloatSliceCmd {
+	args := make([]interface{}, 2+len(members))
+	args[0] = "zmscore"
+	args[1] = key
+	for i, member := range members {
+		args[2+i] = member
 	}
-	if store.Aggregate != "" {
-		args = append(args, "aggregate", store.Aggregate)
+	cmd := NewFloatSliceCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+// Redis `ZRANDMEMBER key count` command. Negative count allows
+// the same member to be returned multiple times.
+func (c *cmdable) ZRandMember(key string, count int64) *StringSliceCmd {
+	cmd := NewStringSliceCmd("zrandmember", key, count)
+	c.process(cmd)
+	return cmd
+}
+
+// Redis `ZRANDMEMBER key count WITHSCORES` command.
+func (c *cmdable) ZRandMemberWithScores(key string, count int64) *ZSliceCmd {
+	cmd := NewZSliceCmd("zrandmember", key, count, "withscores")
+	c.process(cmd)
+	return cmd
+}
+
+func (c *cmdable) ZUnion(store ZStore, keys ...string) *StringSliceCmd {
+	args := zStoreArgs([]interface{}{"zunion"}, store, keys)
+	cmd := NewStringSliceCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+func (c *cmdable) ZUnionWithScores(store ZStore, keys ...string) *ZSliceCmd {
+	args := zStoreArgs([]interface{}{"zunion"}, store, keys)
+	args = append(args, "withscores")
+	cmd := NewZSliceCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+func (c *cmdable) ZInter(store ZStore, keys ...string) *StringSliceCmd {
+	args := zStoreArgs([]interface{}{"zinter"}, store, keys)
+	cmd := NewStringSliceCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+func (c *cmdable) ZInterWithScores(store ZStore, keys ...string) *ZSliceCmd {
+	args := zStoreArgs([]interface{}{"zinter"}, store, keys)
+	args = append(args, "withscores")
+	cmd := NewZSliceCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+func (c *cmdable) ZDiff(keys ...string) *StringSliceCmd {
+	args := zStoreArgs([]interface{}{"zdiff"}, ZStore{}, keys)
+	cmd := NewStringSliceCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+func (c *cmdable) ZDiffWithScores(keys ...string) *ZSliceCmd {
+	args := zStoreArgs([]interface{}{"zdiff"}, ZStore{}, keys)
+	args = append(args, "withscores")
+	cmd := NewZSliceCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+func (c *cmdable) ZDiffStore(destination string, keys ...string) *IntCmd {
+	args := zStoreArgs([]interface{}{"zdiffstore", destination}, ZStore{}, keys)
+	cmd := NewIntCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+// ZRangeArgs is used as an arg to ZRangeStore.
+type ZRangeArgs struct {
+	// Start and Stop are ranks by default, scores with ByScore,
+	// e.g. "(1" or "+inf", and lex ranges with ByLex, e.g. "[a" or "-".
+	Start, Stop interface{}
+
+	ByScore bool
+	ByLex   bool
+	Rev     bool
+
+	// Limit is applied only with ByScore or ByLex and is ignored
+	// for rank ranges.
+	Offset, Count int64
+}
+
+// Redis `ZRANGESTORE dst src min max [BYSCORE|BYLEX] [REV] [LIMIT offset count]`
+// command.
+func (c *cmdable) ZRangeStore(dst, src string, opt ZRangeArgs) *IntCmd {
+	args := []interface{}{"zrangestore", dst, src, opt.Start, opt.Stop}
+	if opt.ByScore {
+		args = append(args, "byscore")
+	} else if opt.ByLex {
+		args = append(args, "bylex")
+	}
+	if opt.Rev {
+		args = append(args, "rev")
+	}
+	if (opt.ByScore || opt.ByLex) && (opt.Offset != 0 || opt.Count != 0) {
+		args = append(args, "limit", opt.Offset, opt.Count)
 	}
 	cmd := NewIntCmd(args...)
 	c.process(cmd)
@@ -1682,18 +2359,85 @@ func (c *cmdable) ClientKill(ipPort string) *StatusCmd {
 	return cmd
 }
 
+// ClientKillFilter selects connections killed by ClientKillByFilter.
+// Connections must match all set filters.
+type ClientKillFilter struct {
+	ID int64
+	// Can be normal, master, replica or pubsub.
+	Type string
+	User string
+	// Client address as ip:port.
+	Addr string
+	// Local address of the server the client is connected to.
+	// Requires Redis 6.2.
+	LAddr string
+	// KillMe allows to kill the connection sending the command.
+	KillMe bool
+}
+
+// Redis `CLIENT KILL <filter> <value> ...` command returns the number
+// of killed connections.
+func (c *cmdable) ClientKillByFilter(filter ClientKillFilter) *IntCmd {
+	args := []interface{}{"client", "kill"}
+	if filter.ID > 0 {
+		args = append(args, "id", filter.ID)
+	}
+	if filter.Type != "" {
+		args = append(args, "type", filter.Type)
+	}
+	if filter.User != "" {
+		args = append(args, "user", filter.User)
+	}
+	if filter.Addr != "" {
+		args = append(args, "addr", filter.Addr)
+	}
+	if filter.LAddr != "" {
+		args = append(args, "laddr", filter.LAddr)
+	}
+	if filter.KillMe {
+		args = append(args, "skipme", "no")
+	}
+	cmd := NewIntCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
 func (c *cmdable) ClientList() *StringCmd {
 	cmd := NewStringCmd("client", "list")
 	c.process(cmd)
 	return cmd
 }
 
+// ClientListInfo is like ClientList, but parses the connections.
+func (c *cmdable) ClientListInfo() *ClientInfoSliceCmd {
+	cmd := NewClientInfoSliceCmd("client", "list")
+	c.process(cmd)
+	return cmd
+}
+
 func (c *cmdable) ClientPause(dur time.Duration) *BoolCmd {
 	cmd := NewBoolCmd("client", "pause", formatMs(dur))
 	c.process(cmd)
 	return cmd
 }
 
+// ClientUnblock unblocks the connection blocked by a blocking command
+// as if the command timed out. It returns 1 if the connection
+// was unblocked.
+func (c *cmdable) ClientUnblock(id int64) *IntCmd {
+	cmd := NewIntCmd("client", "unblock", id)
+	c.process(cmd)
+	return cmd
+}
+
+// ClientUnblockWithError is like ClientUnblock, but the blocking command
+// fails with UNBLOCKED error.
+func (c *cmdable) ClientUnblockWithError(id int64) *IntCmd {
+	cmd := NewIntCmd("client", "unblock", id, "error")
+	c.process(cmd)
+	return cmd
+}
+
 // ClientSetName assigns a name to the connection.
 func (c *statefulCmdable) ClientSetName(name string) *BoolCmd {
 	cmd := NewBoolCmd("client", "setname", name)
@@ -1701,6 +2445,18 @@ func (c *statefulCmdable) ClientSetName(name string) *BoolCmd {
 	return cmd
 }
 
+// ClientNoEvict excludes the connection from client eviction.
+// Requires Redis 7.0.
+func (c *statefulCmdable) ClientNoEvict(on bool) *StatusCmd {
+	mode := "off"
+	if on {
+		mode = "on"
+	}
+	cmd := NewStatusCmd("client", "no-evict", mode)
+	c.process(cmd)
+	return cmd
+}
+
 // ClientGetName returns the name of the connection.
 func (c *cmdable) ClientGetName() *StringCmd {
 	cmd := NewStringCmd("client", "getname")
@@ -1708,6 +2464,21 @@ func (c *cmdable) ClientGetName() *StringCmd {
 	return cmd
 }
 
+// ClientID returns the ID of the connection.
+func (c *cmdable) ClientID() *IntCmd {
+	cmd := NewIntCmd("client", "id")
+	c.process(cmd)
+	return cmd
+}
+
+// ClientInfo returns the connection in CLIENT LIST format.
+// Requires Redis 6.2.
+func (c *cmdable) ClientInfo() *ClientInfoCmd {
+	cmd := NewClientInfoCmd("client", "info")
+	c.process(cmd)
+	return cmd
+}
+
 func (c *cmdable) ConfigGet(parameter string) *SliceCmd {
 	cmd := NewSliceCmd("config", "get", parameter)
 	c.process(cmd)
@@ -1782,6 +2553,17 @@ func (c *cmdable) Info(section ...string) *StringCmd {
 	return cmd
 }
 
+// InfoSections is like Info, but parses the reply into sections.
+func (c *cmdable) InfoSections(section ...string) *InfoCmd {
+	args := []interface{}{"info"}
+	if len(section) > 0 {
+		args = append(args, section[0])
+	}
+	cmd := NewInfoCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
 func (c *cmdable) LastSave() *IntCmd {
 	cmd := NewIntCmd("lastsave")
 	c.process(cmd)
@@ -1834,8 +2616,24 @@ func (c *cmdable) SlaveOf(host, port string) *StatusCmd {
 	return cmd
 }
 
-func (c *cmdable) SlowLog() {
-	panic("not implemented")
+// SlowLogGet returns the latest num slow commands, newest first.
+// Negative num returns the whole slow log.
+func (c *cmdable) SlowLogGet(num int64) *SlowLogCmd {
+	cmd := NewSlowLogCmd("slowlog", "get", num)
+	c.process(cmd)
+	return cmd
+}
+
+func (c *cmdable) SlowLogLen() *IntCmd {
+	cmd := NewIntCmd("slowlog", "len")
+	c.process(cmd)
+	return cmd
+}
+
+func (c *cmdable) SlowLogReset() *StatusCmd {
+	cmd := NewStatusCmd("slowlog", "reset")
+	c.process(cmd)
+	return cmd
 }
 
 func (c *cmdable) Sync() {
@@ -1848,6 +2646,144 @@ func (c *cmdable) Time() *TimeCmd {
 	return cmd
 }
 
+// LatencyLatest returns the latest latency spike of every event.
+// Latency monitor is disabled unless latency-monitor-threshold is set.
+func (c *cmdable) LatencyLatest() *LatencyLatestCmd {
+	cmd := NewLatencyLatestCmd("latency", "latest")
+	c.process(cmd)
+	return cmd
+}
+
+// LatencyHistory returns latency spikes of the event, e.g. "command".
+func (c *cmdable) LatencyHistory(event string) *LatencyHistoryCmd {
+	cmd := NewLatencyHistoryCmd("latency", "history", event)
+	c.process(cmd)
+	return cmd
+}
+
+// LatencyReset resets the events or all events if none are given.
+// It returns the number of reset events.
+func (c *cmdable) LatencyReset(events ...string) *IntCmd {
+	args := make([]interface{}, 2+len(events))
+	args[0] = "latency"
+	args[1] = "reset"
+	for i, event := range events {
+		args[2+i] = event
+	}
+	cmd := NewIntCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+// LatencyDoctor returns a human readable latency analysis report.
+func (c *cmdable) LatencyDoctor() *StringCmd {
+	cmd := NewStringCmd("latency", "doctor")
+	c.process(cmd)
+	return cmd
+}
+
+//------------------------------------------------------------------------------
+
+// ACLSetUser creates the user or modifies its rules,
+// e.g. "on", ">password", "~cached:*" or "+get".
+func (c *cmdable) ACLSetUser(username string, rules ...string) *StatusCmd {
+	args := make([]interface{}, 3+len(rules))
+	args[0] = "acl"
+	args[1] = "setuser"
+	args[2] = username
+	for i, rule := range rules {
+		args[3+i] = rule
+	}
+	cmd := NewStatusCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+// ACLGetUser returns rules of the user or Nil error
+// if the user does not exist.
+func (c *cmdable) ACLGetUser(username string) *ACLUserCmd {
+	cmd := NewACLUserCmd("acl", "getuser", username)
+	c.process(cmd)
+	return cmd
+}
+
+// ACLDelUser deletes the users and returns the number of deleted users.
+func (c *cmdable) ACLDelUser(usernames ...string) *IntCmd {
+	args := make([]interface{}, 2+len(usernames))
+	args[0] = "acl"
+	args[1] = "deluser"
+	for i, username := range usernames {
+		args[2+i] = username
+	}
+	cmd := NewIntCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+// ACLList returns the users in the ACL file format.
+func (c *cmdable) ACLList() *StringSliceCmd {
+	cmd := NewStringSliceCmd("acl", "list")
+	c.process(cmd)
+	return cmd
+}
+
+func (c *cmdable) ACLUsers() *StringSliceCmd {
+	cmd := NewStringSliceCmd("acl", "users")
+	c.process(cmd)
+	return cmd
+}
+
+// ACLWhoAmI returns the user the connection is authenticated as.
+func (c *cmdable) ACLWhoAmI() *StringCmd {
+	cmd := NewStringCmd("acl", "whoami")
+	c.process(cmd)
+	return cmd
+}
+
+// ACLCat returns the command categories.
+func (c *cmdable) ACLCat() *StringSliceCmd {
+	cmd := NewStringSliceCmd("acl", "cat")
+	c.process(cmd)
+	return cmd
+}
+
+// ACLCatCategory returns the commands in the category.
+func (c *cmdable) ACLCatCategory(category string) *StringSliceCmd {
+	cmd := NewStringSliceCmd("acl", "cat", category)
+	c.process(cmd)
+	return cmd
+}
+
+// ACLLog returns at most count recent security events,
+// zero count returns the default number of events.
+func (c *cmdable) ACLLog(count int64) *ACLLogCmd {
+	args := []interface{}{"acl", "log"}
+	if count > 0 {
+		args = append(args, count)
+	}
+	cmd := NewACLLogCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+func (c *cmdable) ACLLogReset() *StatusCmd {
+	cmd := NewStatusCmd("acl", "log", "reset")
+	c.process(cmd)
+	return cmd
+}
+
+// ACLGenPass returns a random password of the given number of bits,
+// zero bits returns a 256-bit password.
+func (c *cmdable) ACLGenPass(bits int) *StringCmd {
+	args := []interface{}{"acl", "genpass"}
+	if bits > 0 {
+		args = append(args, bits)
+	}
+	cmd := NewStringCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
 //------------------------------------------------------------------------------
 
 func (c *cmdable) Eval(script string, keys []string, args ...interface{}) *Cmd {
@@ -1925,6 +2861,14 @@ func (c *cmdable) Publish(channel string, message interface{}) *IntCmd {
 	return cmd
 }
 
+// SPublish posts the message to the shard channel. In Redis Cluster the
+// message is propagated only within the shard owning the channel slot.
+func (c *cmdable) SPublish(channel string, message interface{}) *IntCmd {
+	cmd := NewIntCmd("spublish", channel, message)
+	c.process(cmd)
+	return cmd
+}
+
 func (c *cmdable) PubSubChannels(pattern string) *StringSliceCmd {
 	args := []interface{}{"pubsub", "channels"}
 	if pattern != "*" {
@@ -1967,6 +2911,13 @@ func (c *cmdable) ClusterNodes() *StringCmd {
 	return cmd
 }
 
+// ClusterNodesInfo is like ClusterNodes, but returns parsed reply.
+func (c *cmdable) ClusterNodesInfo() *ClusterNodesCmd {
+	cmd := NewClusterNodesCmd("cluster", "nodes")
+	c.process(cmd)
+	return cmd
+}
+
 func (c *cmdable) ClusterMeet(host, port string) *StatusCmd {
 	cmd := NewStatusCmd("cluster", "meet", host, port)
 	c.process(cmd)
@@ -2093,6 +3044,36 @@ func (c *cmdable) ClusterAddSlotsRange(min, max int) *StatusCmd {
 	return c.ClusterAddSlots(slots...)
 }
 
+func (c *cmdable) ClusterSetSlotImporting(slot int, nodeID string) *StatusCmd {
+	cmd := NewStatusCmd("cluster", "setslot", slot, "importing", nodeID)
+	c.process(cmd)
+	return cmd
+}
+
+func (c *cmdable) ClusterSetSlotMigrating(slot int, nodeID string) *StatusCmd {
+	cmd := NewStatusCmd("cluster", "setslot", slot, "migrating", nodeID)
+	c.process(cmd)
+	return cmd
+}
+
+func (c *cmdable) ClusterSetSlotNode(slot int, nodeID string) *StatusCmd {
+	cmd := NewStatusCmd("cluster", "setslot", slot, "node", nodeID)
+	c.process(cmd)
+	return cmd
+}
+
+func (c *cmdable) ClusterSetSlotStable(slot int) *StatusCmd {
+	cmd := NewStatusCmd("cluster", "setslot", slot, "stable")
+	c.process(cmd)
+	return cmd
+}
+
+func (c *cmdable) ClusterGetKeysInSlot(slot int, count int) *StringSliceCmd {
+	cmd := NewStringSliceCmd("cluster", "getkeysinslot", slot, count)
+	c.process(cmd)
+	return cmd
+}
+
 //------------------------------------------------------------------------------
 
 func (c *cmdable) GeoAdd(key string, geoLocation ...*GeoLocation) *IntCmd {
@@ -2133,6 +3114,45 @@ func (c *cmdable) GeoRadiusByMemberRO(key, member string, query *GeoRadiusQuery)
 	return cmd
 }
 
+// GeoRadiusStore is GeoRadius with Store or StoreDist query option.
+// It returns the number of stored locations.
+func (c *cmdable) GeoRadiusStore(key string, longitude, latitude float64, query *GeoRadiusQuery) *IntCmd {
+	args := NewGeoLocationCmd(query, "georadius", key, longitude, latitude).Args()
+	cmd := NewIntCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+// GeoRadiusByMemberStore is GeoRadiusByMember with Store or StoreDist
+// query option. It returns the number of stored locations.
+func (c *cmdable) GeoRadiusByMemberStore(key, member string, query *GeoRadiusQuery) *IntCmd {
+	args := NewGeoLocationCmd(query, "georadiusbymember", key, member).Args()
+	cmd := NewIntCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
+// Redis `GEOSEARCH key FROMMEMBER|FROMLONLAT BYRADIUS|BYBOX ...` command.
+// Requires Redis 6.2.
+func (c *cmdable) GeoSearch(key string, query *GeoSearchQuery) *GeoLocationCmd {
+	cmd := NewGeoSearchCmd(query, "geosearch", key)
+	c.process(cmd)
+	return cmd
+}
+
+// Redis `GEOSEARCHSTORE destination source ... [STOREDIST]` command
+// stores members found by the query and returns their number.
+// WITH* query options are not supported by the command and ignored.
+func (c *cmdable) GeoSearchStore(key, store string, query *GeoSearchQuery, storeDist bool) *IntCmd {
+	args := query.appendArgs([]interface{}{"geosearchstore", store, key})
+	if storeDist {
+		args = append(args, "storedist")
+	}
+	cmd := NewIntCmd(args...)
+	c.process(cmd)
+	return cmd
+}
+
 func (c *cmdable) GeoDist(key string, member1, member2, unit string) *FloatCmd {
 	if unit == "" {
 		unit = "km"
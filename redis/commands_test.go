@@ -115,6 +115,81 @@ var _ = Describe("Commands", func() {
 			Expect(r.Val()).To(Equal(""))
 		})
 
+		It("should ClientKillByFilter", func() {
+			r := client.ClientKillByFilter(redis.ClientKillFilter{Addr: "1.1.1.1:1111"})
+			Expect(r.Err()).NotTo(HaveOccurred())
+			Expect(r.Val()).To(Equal(int64(0)))
+		})
+
+		It("should ClientID and ClientListInfo", func() {
+			id, err := client.ClientID().Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(BeNumerically(">", 0))
+
+			infos, err := client.ClientListInfo().Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(infos).NotTo(BeEmpty())
+
+			var found bool
+			for _, info := range infos {
+				if info.ID == id {
+					found = true
+					Expect(info.Addr).NotTo(BeEmpty())
+					Expect(info.LastCmd).To(HavePrefix("client"))
+				}
+			}
+			Expect(found).To(BeTrue())
+		})
+
+		It("should ClientNoEvict and ClientInfo", func() {
+			pipe := client.Pipeline()
+			on := pipe.ClientNoEvict(true)
+			info := pipe.ClientInfo()
+			off := pipe.ClientNoEvict(false)
+			_, err := pipe.Exec()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(on.Val()).To(Equal("OK"))
+			Expect(off.Val()).To(Equal("OK"))
+			Expect(info.Val().ID).To(BeNumerically(">", 0))
+			Expect(info.Val().DB).To(Equal(15))
+			Expect(info.Val().Flags).To(ContainSubstring("e"))
+			Expect(info.Val().LastCmd).To(HavePrefix("client"))
+		})
+
+		It("should ClientUnblock", func() {
+			id := client.ClientID().Val()
+			r, err := client.ClientUnblock(id).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r).To(Equal(int64(0)))
+		})
+
+		It("should ProcessNoReply", func() {
+			set := redis.NewStatusCmd("set", "noreply", "value")
+			err := client.ProcessNoReply(set)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(client.Get("noreply").Val()).To(Equal("value"))
+		})
+
+		It("should ProcessNoReply bypassing WrapProcess", func() {
+			var processed int
+			client.WrapProcess(func(old func(redis.Cmder) error) func(redis.Cmder) error {
+				return func(cmd redis.Cmder) error {
+					processed++
+					return old(cmd)
+				}
+			})
+
+			set := redis.NewStatusCmd("set", "noreply", "value")
+			err := client.ProcessNoReply(set)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processed).To(Equal(0))
+
+			Expect(client.Get("noreply").Val()).To(Equal("value"))
+			Expect(processed).To(Equal(1))
+		})
+
 		It("should ClientPause", func() {
 			err := client.ClientPause(time.Second).Err()
 			Expect(err).NotTo(HaveOccurred())
@@ -180,6 +255,28 @@ var _ = Describe("Commands", func() {
 			Expect(info.Val()).NotTo(Equal(""))
 		})
 
+		It("should InfoSections", func() {
+			err := client.Set("key", "hello", time.Hour).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			info, err := client.InfoSections().Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Field("server", "redis_version")).NotTo(BeEmpty())
+			Expect(info.UsedMemory()).To(BeNumerically(">", 0))
+			Expect(info.ConnectedClients()).To(BeNumerically(">=", 1))
+			Expect(info.Role()).To(Equal("master"))
+			Expect(info.MasterLinkStatus()).To(BeEmpty())
+
+			ks := info.Keyspace()[15]
+			Expect(ks.Keys).To(Equal(int64(1)))
+			Expect(ks.Expires).To(Equal(int64(1)))
+
+			info, err = client.InfoSections("cpu").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info).To(HaveKey("cpu"))
+			Expect(info).NotTo(HaveKey("server"))
+		})
+
 		It("should Info cpu", func() {
 			info := client.Info("cpu")
 			Expect(info.Err()).NotTo(HaveOccurred())
@@ -216,6 +313,145 @@ var _ = Describe("Commands", func() {
 			Expect(tm).To(BeTemporally("~", time.Now(), 3*time.Second))
 		})
 
+		It("should SlowLogGet", func() {
+			err := client.ConfigSet("slowlog-log-slower-than", "0").Err()
+			Expect(err).NotTo(HaveOccurred())
+			defer client.ConfigSet("slowlog-log-slower-than", "10000")
+
+			err = client.SlowLogReset().Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = client.Set("slowlog", "value", 0).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			n, err := client.SlowLogLen().Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(BeNumerically(">", 0))
+
+			logs, err := client.SlowLogGet(-1).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logs).NotTo(BeEmpty())
+
+			var found bool
+			for _, log := range logs {
+				if len(log.Args) > 0 && log.Args[0] == "set" {
+					found = true
+					Expect(log.Args).To(Equal([]string{"set", "slowlog", "value"}))
+					Expect(log.Time).To(BeTemporally("~", time.Now(), 3*time.Second))
+				}
+			}
+			Expect(found).To(BeTrue())
+		})
+
+		It("should LatencyLatest", func() {
+			err := client.LatencyReset().Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := client.LatencyLatest().Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(BeEmpty())
+
+			samples, err := client.LatencyHistory("command").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(samples).To(BeEmpty())
+
+			doctor, err := client.LatencyDoctor().Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(doctor).NotTo(BeEmpty())
+		})
+
+		Describe("ACL", func() {
+			AfterEach(func() {
+				err := client.ACLDelUser("acl-test").Err()
+				Expect(err).NotTo(HaveOccurred())
+				err = client.ACLLogReset().Err()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should SetUser and GetUser", func() {
+				err := client.ACLSetUser("acl-test", "on", ">secret", "~cached:*", "+get").Err()
+				Expect(err).NotTo(HaveOccurred())
+
+				user, err := client.ACLGetUser("acl-test").Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(user.Flags).To(ContainElement("on"))
+				Expect(user.Passwords).To(HaveLen(1))
+				Expect(user.Commands).To(ContainSubstring("+get"))
+				Expect(user.Keys).To(HaveLen(1))
+				Expect(user.Keys[0]).To(HaveSuffix("cached:*"))
+
+				_, err = client.ACLGetUser("acl-missing").Result()
+				Expect(err).To(Equal(redis.Nil))
+			})
+
+			It("should List, Users and DelUser", func() {
+				err := client.ACLSetUser("acl-test", "off").Err()
+				Expect(err).NotTo(HaveOccurred())
+
+				users, err := client.ACLUsers().Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(users).To(ContainElement("acl-test"))
+
+				list, err := client.ACLList().Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(list).To(ContainElement(HavePrefix("user acl-test off")))
+
+				n, err := client.ACLDelUser("acl-test", "acl-missing").Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(n).To(Equal(int64(1)))
+			})
+
+			It("should WhoAmI, Cat and GenPass", func() {
+				name, err := client.ACLWhoAmI().Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(name).To(Equal("default"))
+
+				cats, err := client.ACLCat().Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cats).To(ContainElement("string"))
+
+				cmds, err := client.ACLCatCategory("string").Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cmds).To(ContainElement("get"))
+
+				pass, err := client.ACLGenPass(0).Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pass).To(HaveLen(64))
+
+				pass, err = client.ACLGenPass(32).Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pass).To(HaveLen(8))
+			})
+
+			It("should authenticate as ACL user", func() {
+				err := client.ACLSetUser("acl-test", "on", ">secret", "~*", "+@all").Err()
+				Expect(err).NotTo(HaveOccurred())
+
+				opt := redisOptions()
+				opt.Username = "acl-test"
+				opt.Password = "secret"
+				userClient := redis.NewClient(opt)
+				defer userClient.Close()
+
+				name, err := userClient.ACLWhoAmI().Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(name).To(Equal("acl-test"))
+
+				opt.Password = "wrong"
+				badClient := redis.NewClient(opt)
+				defer badClient.Close()
+
+				err = badClient.Ping().Err()
+				Expect(err).To(HaveOccurred())
+
+				entries, err := client.ACLLog(10).Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).NotTo(BeEmpty())
+				Expect(entries[0].Reason).To(Equal("auth"))
+				Expect(entries[0].Username).To(Equal("acl-test"))
+			})
+		})
+
 		It("Should Command", func() {
 			cmds, err := client.Command().Result()
 			Expect(err).NotTo(HaveOccurred())
@@ -290,6 +526,48 @@ var _ = Describe("Commands", func() {
 			Expect(dump.Val()).NotTo(BeEmpty())
 		})
 
+		It("should Copy", func() {
+			err := client.Set("key", "hello", 0).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			n, err := client.Copy("key", "key2", false).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(1)))
+
+			n, err = client.Copy("key", "key2", false).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(0)))
+
+			err = client.Set("key", "world", 0).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			n, err = client.Copy("key", "key2", true).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(1)))
+
+			val, err := client.Get("key2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("world"))
+		})
+
+		It("should CopyToDB", func() {
+			err := client.Set("key", "hello", 0).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			n, err := client.CopyToDB("key", "key", 2, false).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(1)))
+
+			pipe := client.Pipeline()
+			pipe.Select(2)
+			get := pipe.Get("key")
+			pipe.FlushDB()
+
+			_, err = pipe.Exec()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(get.Val()).To(Equal("hello"))
+		})
+
 		It("should Exists", func() {
 			set := client.Set("key1", "Hello", 0)
 			Expect(set.Err()).NotTo(HaveOccurred())
@@ -921,6 +1199,42 @@ var _ = Describe("Commands", func() {
 			Expect(pos).To(Equal(int64(-1)))
 		})
 
+		It("should BitField", func() {
+			u8 := redis.BitFieldUnsigned(8)
+
+			vals, err := client.BitField("mykey", new(redis.BitFieldArgs).
+				IncrBy(u8, redis.BitFieldIndex(0), 200).
+				Overflow(redis.BitFieldSat).
+				IncrBy(u8, redis.BitFieldIndex(0), 100).
+				Set(u8, redis.BitFieldIndex(1), 250).
+				Get(redis.BitFieldSigned(4), redis.BitFieldBit(0)),
+			).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vals).To(Equal([]int64{200, 255, 0, -1}))
+
+			cmd := client.BitField("mykey", new(redis.BitFieldArgs).
+				Overflow(redis.BitFieldFail).
+				IncrBy(u8, redis.BitFieldIndex(1), 10).
+				Get(u8, redis.BitFieldIndex(1)),
+			)
+			Expect(cmd.Err()).NotTo(HaveOccurred())
+			Expect(cmd.Val()).To(Equal([]int64{0, 250}))
+			Expect(cmd.Nil(0)).To(BeTrue())
+			Expect(cmd.Nil(1)).To(BeFalse())
+		})
+
+		It("should BitFieldRO", func() {
+			err := client.Set("mykey", "\xff\x01", 0).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			vals, err := client.BitFieldRO("mykey", new(redis.BitFieldArgs).
+				Get(redis.BitFieldUnsigned(8), redis.BitFieldIndex(0)).
+				Get(redis.BitFieldUnsigned(8), redis.BitFieldIndex(1)),
+			).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vals).To(Equal([]int64{255, 1}))
+		})
+
 		It("should Decr", func() {
 			set := client.Set("key", "10", 0)
 			Expect(set.Err()).NotTo(HaveOccurred())
@@ -1017,6 +1331,50 @@ var _ = Describe("Commands", func() {
 			Expect(get.Val()).To(Equal("0"))
 		})
 
+		It("should GetEx", func() {
+			err := client.Set("key", "hello", 0).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			val, err := client.GetEx("key", time.Minute).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("hello"))
+
+			ttl := client.TTL("key")
+			Expect(ttl.Err()).NotTo(HaveOccurred())
+			Expect(ttl.Val()).To(BeNumerically("~", time.Minute, time.Second))
+
+			val, err = client.GetEx("key", 0).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("hello"))
+
+			ttl = client.TTL("key")
+			Expect(ttl.Err()).NotTo(HaveOccurred())
+			Expect(ttl.Val()).To(Equal(-time.Second))
+
+			val, err = client.GetExAt("key", time.Now().Add(time.Hour)).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("hello"))
+
+			ttl = client.TTL("key")
+			Expect(ttl.Err()).NotTo(HaveOccurred())
+			Expect(ttl.Val()).To(BeNumerically("~", time.Hour, time.Second))
+
+			_, err = client.GetEx("_", time.Minute).Result()
+			Expect(err).To(Equal(redis.Nil))
+		})
+
+		It("should GetDel", func() {
+			err := client.Set("key", "hello", 0).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			val, err := client.GetDel("key").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("hello"))
+
+			_, err = client.GetDel("key").Result()
+			Expect(err).To(Equal(redis.Nil))
+		})
+
 		It("should Incr", func() {
 			set := client.Set("key", "10", 0)
 			Expect(set.Err()).NotTo(HaveOccurred())
@@ -1136,6 +1494,60 @@ var _ = Describe("Commands", func() {
 			Expect(val).To(Equal("hello"))
 		})
 
+		It("should SetArgs with NX and XX", func() {
+			err := client.SetArgs("key", "hello", redis.SetArgs{Mode: "xx"}).Err()
+			Expect(err).To(Equal(redis.Nil))
+
+			val, err := client.SetArgs("key", "hello", redis.SetArgs{Mode: "nx"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("OK"))
+
+			err = client.SetArgs("key", "hello2", redis.SetArgs{Mode: "nx"}).Err()
+			Expect(err).To(Equal(redis.Nil))
+
+			val, err = client.SetArgs("key", "hello2", redis.SetArgs{Mode: "xx"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("OK"))
+		})
+
+		It("should SetArgs with Get", func() {
+			_, err := client.SetArgs("key", "hello", redis.SetArgs{Get: true}).Result()
+			Expect(err).To(Equal(redis.Nil))
+
+			val, err := client.SetArgs("key", "hello2", redis.SetArgs{Get: true}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("hello"))
+
+			val, err = client.Get("key").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("hello2"))
+		})
+
+		It("should SetArgs with expiration", func() {
+			err := client.SetArgs("key", "hello", redis.SetArgs{TTL: time.Minute}).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			ttl := client.TTL("key")
+			Expect(ttl.Err()).NotTo(HaveOccurred())
+			Expect(ttl.Val()).To(BeNumerically("~", time.Minute, time.Second))
+
+			err = client.SetArgs("key", "hello2", redis.SetArgs{KeepTTL: true}).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			ttl = client.TTL("key")
+			Expect(ttl.Err()).NotTo(HaveOccurred())
+			Expect(ttl.Val()).To(BeNumerically("~", time.Minute, time.Second))
+
+			err = client.SetArgs("key", "hello3", redis.SetArgs{
+				ExpireAt: time.Now().Add(time.Hour),
+			}).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			ttl = client.TTL("key")
+			Expect(ttl.Err()).NotTo(HaveOccurred())
+			Expect(ttl.Val()).To(BeNumerically("~", time.Hour, time.Second))
+		})
+
 		It("should SetXX", func() {
 			isSet, err := client.SetXX("key", "hello2", 0).Result()
 			Expect(err).NotTo(HaveOccurred())
@@ -1184,6 +1596,30 @@ var _ = Describe("Commands", func() {
 			Expect(get.Val()).To(Equal("Hello Redis"))
 		})
 
+		It("should LCS", func() {
+			err := client.MSet("key1", "ohmytext", "key2", "mynewtext").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			val, err := client.LCS("key1", "key2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("mytext"))
+
+			n, err := client.LCSLen("key1", "key2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(6)))
+
+			idx, err := client.LCSIdx("key1", "key2", 4).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(idx).To(Equal(redis.LCSIdx{
+				Matches: []redis.LCSMatch{{
+					A:   redis.LCSPosition{Start: 4, End: 7},
+					B:   redis.LCSPosition{Start: 5, End: 8},
+					Len: 4,
+				}},
+				Len: 6,
+			}))
+		})
+
 		It("should StrLen", func() {
 			set := client.Set("key", "hello", 0)
 			Expect(set.Err()).NotTo(HaveOccurred())
@@ -1518,6 +1954,35 @@ var _ = Describe("Commands", func() {
 			Expect(v).To(Equal("c"))
 		})
 
+		It("should BLMove", func() {
+			_, err := client.BLMove("list1", "list2", redis.ListRight, redis.ListLeft, time.Second).Result()
+			Expect(err).To(Equal(redis.Nil))
+
+			err = client.RPush("list1", "a", "b", "c").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			v, err := client.BLMove("list1", "list2", redis.ListRight, redis.ListLeft, 0).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal("c"))
+
+			lRange := client.LRange("list2", 0, -1)
+			Expect(lRange.Err()).NotTo(HaveOccurred())
+			Expect(lRange.Val()).To(Equal([]string{"c"}))
+		})
+
+		It("should BLMPop", func() {
+			_, _, err := client.BLMPop(time.Second, redis.ListLeft, 1, "list1", "list2").Result()
+			Expect(err).To(Equal(redis.Nil))
+
+			err = client.RPush("list2", "a", "b", "c").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			key, vals, err := client.BLMPop(0, redis.ListLeft, 2, "list1", "list2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal("list2"))
+			Expect(vals).To(Equal([]string{"a", "b"}))
+		})
+
 		It("should LIndex", func() {
 			lPush := client.LPush("list", "World")
 			Expect(lPush.Err()).NotTo(HaveOccurred())
@@ -1563,6 +2028,39 @@ var _ = Describe("Commands", func() {
 			Expect(lLen.Val()).To(Equal(int64(2)))
 		})
 
+		It("should LMove", func() {
+			err := client.RPush("list1", "one", "two", "three").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			v, err := client.LMove("list1", "list2", redis.ListRight, redis.ListLeft).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal("three"))
+
+			v, err = client.LMove("list1", "list2", redis.ListLeft, redis.ListRight).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal("one"))
+
+			lRange := client.LRange("list2", 0, -1)
+			Expect(lRange.Err()).NotTo(HaveOccurred())
+			Expect(lRange.Val()).To(Equal([]string{"three", "one"}))
+
+			_, err = client.LMove("_", "list2", redis.ListLeft, redis.ListLeft).Result()
+			Expect(err).To(Equal(redis.Nil))
+		})
+
+		It("should LMPop", func() {
+			_, _, err := client.LMPop(redis.ListLeft, 1, "list1", "list2").Result()
+			Expect(err).To(Equal(redis.Nil))
+
+			err = client.RPush("list2", "one", "two", "three").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			key, vals, err := client.LMPop(redis.ListRight, 2, "list1", "list2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal("list2"))
+			Expect(vals).To(Equal([]string{"three", "two"}))
+		})
+
 		It("should LPop", func() {
 			rPush := client.RPush("list", "one")
 			Expect(rPush.Err()).NotTo(HaveOccurred())
@@ -1580,6 +2078,58 @@ var _ = Describe("Commands", func() {
 			Expect(lRange.Val()).To(Equal([]string{"two", "three"}))
 		})
 
+		It("should LPopCount", func() {
+			err := client.RPush("list", "one", "two", "three").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			vals, err := client.LPopCount("list", 2).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vals).To(Equal([]string{"one", "two"}))
+
+			_, err = client.LPopCount("_", 2).Result()
+			Expect(err).To(Equal(redis.Nil))
+		})
+
+		It("should LPos", func() {
+			err := client.RPush("list", "a", "b", "c", "1", "2", "3", "c", "c").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			n, err := client.LPos("list", "c", redis.LPosArgs{}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(2)))
+
+			n, err = client.LPos("list", "c", redis.LPosArgs{Rank: 2}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(6)))
+
+			n, err = client.LPos("list", "c", redis.LPosArgs{Rank: -1}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(7)))
+
+			_, err = client.LPos("list", "c", redis.LPosArgs{MaxLen: 2}).Result()
+			Expect(err).To(Equal(redis.Nil))
+
+			_, err = client.LPos("list", "x", redis.LPosArgs{}).Result()
+			Expect(err).To(Equal(redis.Nil))
+		})
+
+		It("should LPosCount", func() {
+			err := client.RPush("list", "a", "b", "c", "1", "2", "3", "c", "c").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			ns, err := client.LPosCount("list", "c", 2, redis.LPosArgs{}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ns).To(Equal([]int64{2, 6}))
+
+			ns, err = client.LPosCount("list", "c", 0, redis.LPosArgs{Rank: -1}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ns).To(Equal([]int64{7, 6, 2}))
+
+			ns, err = client.LPosCount("list", "x", 0, redis.LPosArgs{}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ns).To(BeEmpty())
+		})
+
 		It("should LPush", func() {
 			lPush := client.LPush("list", "World")
 			Expect(lPush.Err()).NotTo(HaveOccurred())
@@ -1711,6 +2261,15 @@ var _ = Describe("Commands", func() {
 			Expect(lRange.Val()).To(Equal([]string{"one", "two"}))
 		})
 
+		It("should RPopCount", func() {
+			err := client.RPush("list", "one", "two", "three").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			vals, err := client.RPopCount("list", 2).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vals).To(Equal([]string{"three", "two"}))
+		})
+
 		It("should RPopLPush", func() {
 			rPush := client.RPush("list", "one")
 			Expect(rPush.Err()).NotTo(HaveOccurred())
@@ -1879,6 +2438,21 @@ var _ = Describe("Commands", func() {
 			Expect(sInter.Val()).To(Equal([]string{"c"}))
 		})
 
+		It("should SInterCard", func() {
+			err := client.SAdd("set1", "a", "b", "c", "d").Err()
+			Expect(err).NotTo(HaveOccurred())
+			err = client.SAdd("set2", "b", "c", "d", "e").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			n, err := client.SInterCard(0, "set1", "set2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(3)))
+
+			n, err = client.SInterCard(2, "set1", "set2").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(2)))
+		})
+
 		It("should SInterStore", func() {
 			sAdd := client.SAdd("set1", "a")
 			Expect(sAdd.Err()).NotTo(HaveOccurred())
@@ -1916,6 +2490,15 @@ var _ = Describe("Commands", func() {
 			Expect(sIsMember.Val()).To(Equal(false))
 		})
 
+		It("should SMIsMember", func() {
+			err := client.SAdd("set", "one").Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			vals, err := client.SMIsMember("set", "one", "two").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vals).To(Equal([]bool{true, false}))
+		})
+
 		It("should SMembers", func() {
 			sAdd := client.SAdd("set", "Hello")
 			Expect(sAdd.Err()).NotTo(HaveOccurred())
@@ -3016,6 +3599,230 @@ var _ = Describe("Commands", func() {
 			}}))
 		})
 
+		It("should ZPopMin and ZPopMax", func() {
+			err := client.ZAdd("zset",
+				redis.Z{Score: 1, Member: "one"},
+				redis.Z{Score: 2, Member: "two"},
+				redis.Z{Score: 3, Member: "three"},
+				redis.Z{Score: 4, Member: "four"},
+			).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			val, err := client.ZPopMin("zset").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal([]redis.Z{{Score: 1, Member: "one"}}))
+
+			val, err = client.ZPopMax("zset", 2).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal([]redis.Z{
+				{Score: 4, Member: "four"},
+				{Score: 3, Member: "three"},
+			}))
+
+			val, err = client.ZPopMin("zset", 10).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal([]redis.Z{{Score: 2, Member: "two"}}))
+
+			val, err = client.ZPopMax("zset").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(BeEmpty())
+		})
+
+		It("should BZPopMin and BZPopMax", func() {
+			err := client.ZAdd("zset1",
+				redis.Z{Score: 1, Member: "one"},
+				redis.Z{Score: 2, Member: "two"},
+			).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			val, err := client.BZPopMin(0, "zset0", "zset1").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal(redis.ZWithKey{
+				Z:   redis.Z{Score: 1, Member: "one"},
+				Key: "zset1",
+			}))
+
+			val, err = client.BZPopMax(0, "zset0", "zset1").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal(redis.ZWithKey{
+				Z:   redis.Z{Score: 2, Member: "two"},
+				Key: "zset1",
+			}))
+		})
+
+		It("should BZPopMin blocks", func() {
+			started := make(chan bool)
+			done := make(chan bool)
+			go func() {
+				defer GinkgoRecover()
+
+				started <- true
+				val, err := client.BZPopMin(0, "zset").Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(val).To(Equal(redis.ZWithKey{
+					Z:   redis.Z{Score: 1, Member: "one"},
+					Key: "zset",
+				}))
+				done <- true
+			}()
+			<-started
+
+			select {
+			case <-done:
+				Fail("BZPopMin is not blocked")
+			case <-time.After(time.Second):
+				// ok
+			}
+
+			err := client.ZAdd("zset", redis.Z{Score: 1, Member: "one"}).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			select {
+			case <-done:
+				// ok
+			case <-time.After(time.Second):
+				Fail("BZPopMin is still blocked")
+			}
+		})
+
+		It("should BZPopMax timeout", func() {
+			_, err := client.BZPopMax(time.Second, "zset").Result()
+			Expect(err).To(Equal(redis.Nil))
+
+			Expect(client.Ping().Err()).NotTo(HaveOccurred())
+
+			stats := client.PoolStats()
+			Expect(stats.Timeouts).To(Equal(uint32(0)))
+		})
+
+		It("should ZMScore", func() {
+			err := client.ZAdd("zset",
+				redis.Z{Score: 1, Member: "one"},
+				redis.Z{Score: 2.5, Member: "two"},
+			).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			cmd := client.ZMScore("zset", "one", "missing", "two")
+			Expect(cmd.Err()).NotTo(HaveOccurred())
+			Expect(cmd.Val()).To(Equal([]float64{1, 0, 2.5}))
+			Expect(cmd.Nil(0)).To(BeFalse())
+			Expect(cmd.Nil(1)).To(BeTrue())
+		})
+
+		It("should ZRandMember", func() {
+			err := client.ZAdd("zset",
+				redis.Z{Score: 1, Member: "one"},
+				redis.Z{Score: 2, Member: "two"},
+			).Err()
+			Expect(err).NotTo(HaveOccurred())
+
+			members, err := client.ZRandMember("zset", 2).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(ConsistOf("one", "two"))
+
+			members, err = client.ZRandMember("zset", -5).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(HaveLen(5))
+
+			zz, err := client.ZRandMemberWithScores("zset", 2).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zz).To(ConsistOf(
+				redis.Z{Score: 1, Member: "one"},
+				redis.Z{Score: 2, Member: "two"},
+			))
+		})
+
+		Describe("set operations", func() {
+			BeforeEach(func() {
+				err := client.ZAdd("zset1",
+					redis.Z{Score: 1, Member: "one"},
+					redis.Z{Score: 2, Member: "two"},
+				).Err()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = client.ZAdd("zset2",
+					redis.Z{Score: 1, Member: "one"},
+					redis.Z{Score: 2, Member: "two"},
+					redis.Z{Score: 3, Member: "three"},
+				).Err()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should ZUnion", func() {
+				members, err := client.ZUnion(redis.ZStore{}, "zset1", "zset2").Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(members).To(Equal([]string{"one", "three", "two"}))
+
+				zz, err := client.ZUnionWithScores(
+					redis.ZStore{Weights: []float64{2, 3}}, "zset1", "zset2").Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(zz).To(Equal([]redis.Z{
+					{Score: 5, Member: "one"},
+					{Score: 9, Member: "three"},
+					{Score: 10, Member: "two"},
+				}))
+			})
+
+			It("should ZInter", func() {
+				members, err := client.ZInter(redis.ZStore{}, "zset1", "zset2").Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(members).To(Equal([]string{"one", "two"}))
+
+				zz, err := client.ZInterWithScores(
+					redis.ZStore{Aggregate: "max"}, "zset1", "zset2").Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(zz).To(Equal([]redis.Z{
+					{Score: 1, Member: "one"},
+					{Score: 2, Member: "two"},
+				}))
+			})
+
+			It("should ZDiff and ZDiffStore", func() {
+				members, err := client.ZDiff("zset2", "zset1").Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(members).To(Equal([]string{"three"}))
+
+				zz, err := client.ZDiffWithScores("zset2", "zset1").Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(zz).To(Equal([]redis.Z{{Score: 3, Member: "three"}}))
+
+				n, err := client.ZDiffStore("out", "zset2", "zset1").Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(n).To(Equal(int64(1)))
+			})
+
+			It("should ZRangeStore", func() {
+				n, err := client.ZRangeStore("out", "zset2", redis.ZRangeArgs{
+					Start: 0,
+					Stop:  1,
+				}).Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(n).To(Equal(int64(2)))
+
+				// Limit is ignored for rank ranges.
+				n, err = client.ZRangeStore("out", "zset2", redis.ZRangeArgs{
+					Start: 0,
+					Stop:  -1,
+					Count: 1,
+				}).Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(n).To(Equal(int64(3)))
+
+				n, err = client.ZRangeStore("out", "zset2", redis.ZRangeArgs{
+					Start:   "+inf",
+					Stop:    "(1",
+					ByScore: true,
+					Rev:     true,
+					Count:   1,
+				}).Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(n).To(Equal(int64(1)))
+
+				val, err := client.ZRangeWithScores("out", 0, -1).Result()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(val).To(Equal([]redis.Z{{Score: 3, Member: "three"}}))
+			})
+		})
 	})
 
 	Describe("Geo add and radius search", func() {
@@ -3131,6 +3938,98 @@ var _ = Describe("Commands", func() {
 			Expect(res).To(HaveLen(0))
 		})
 
+		It("should store geo radius search results", func() {
+			n, err := client.GeoRadiusStore("Sicily", 15, 37, &redis.GeoRadiusQuery{
+				Radius: 200,
+				Store:  "result",
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(2)))
+
+			n, err = client.GeoRadiusByMemberStore("Sicily", "Catania", &redis.GeoRadiusQuery{
+				Radius:    100,
+				StoreDist: "result",
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(1)))
+
+			res, err := client.ZRangeWithScores("result", 0, -1).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal([]redis.Z{{Score: 0, Member: "Catania"}}))
+		})
+
+		It("should geo search by member and radius", func() {
+			res, err := client.GeoSearch("Sicily", &redis.GeoSearchQuery{
+				Member: "Catania",
+				Radius: 200,
+				Sort:   "ASC",
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal([]redis.GeoLocation{
+				{Name: "Catania"},
+				{Name: "Palermo"},
+			}))
+		})
+
+		It("should geo search by coordinates and box with options", func() {
+			res, err := client.GeoSearch("Sicily", &redis.GeoSearchQuery{
+				Longitude:   15,
+				Latitude:    37,
+				BoxWidth:    400,
+				BoxHeight:   400,
+				Unit:        "km",
+				Sort:        "ASC",
+				Count:       1,
+				WithCoord:   true,
+				WithDist:    true,
+				WithGeoHash: true,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(HaveLen(1))
+			Expect(res[0].Name).To(Equal("Catania"))
+			Expect(res[0].Dist).To(Equal(56.4413))
+			Expect(res[0].GeoHash).To(Equal(int64(3479447370796909)))
+			Expect(res[0].Longitude).To(Equal(15.087267458438873))
+			Expect(res[0].Latitude).To(Equal(37.50266842333162))
+		})
+
+		It("should geo search with count any", func() {
+			res, err := client.GeoSearch("Sicily", &redis.GeoSearchQuery{
+				Longitude: 15,
+				Latitude:  37,
+				Radius:    200,
+				Count:     1,
+				CountAny:  true,
+			}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(HaveLen(1))
+		})
+
+		It("should geo search and store", func() {
+			n, err := client.GeoSearchStore("Sicily", "result", &redis.GeoSearchQuery{
+				Longitude: 15,
+				Latitude:  37,
+				Radius:    200,
+			}, false).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(2)))
+
+			pos, err := client.GeoPos("result", "Palermo").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pos[0]).NotTo(BeNil())
+
+			n, err = client.GeoSearchStore("Sicily", "result", &redis.GeoSearchQuery{
+				Member: "Catania",
+				Radius: 100,
+			}, true).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(1)))
+
+			res, err := client.ZRangeWithScores("result", 0, -1).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal([]redis.Z{{Score: 0, Member: "Catania"}}))
+		})
+
 		It("should get geo distance with unit options", func() {
 			// From Redis CLI, note the difference in rounding in m vs
 			// km on Redis itself.
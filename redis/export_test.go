@@ -44,3 +44,50 @@ func (c *ClusterClient) SwapSlotNodes(slot int) {
 		nodes[0], nodes[1] = nodes[1], nodes[0]
 	}
 }
+
+func ParseClusterNodes(s string) ([]ClusterNodeInfo, error) {
+	return parseClusterNodes(s)
+}
+
+func ClusterNodesSlots(nodes []ClusterNodeInfo, origin string) []ClusterSlot {
+	return clusterNodesSlots(nodes, origin)
+}
+
+// CheckClusterNodes checks cluster consistency using CLUSTER NODES
+// replies of the nodes. CLUSTER SLOTS replies are derived from them.
+func CheckClusterNodes(replies ...string) (*ClusterCheck, error) {
+	views := make([]clusterNodeView, len(replies))
+	for i, reply := range replies {
+		nodes, err := parseClusterNodes(reply)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			if node.HasFlag("myself") {
+				views[i].addr = node.Addr
+			}
+		}
+		views[i].nodes = nodes
+		views[i].slots = clusterNodesSlots(nodes, views[i].addr)
+	}
+	return checkCluster(views), nil
+}
+
+func SentinelReplicaAddrs(replicas []map[string]string) []string {
+	return replicaAddrs(replicas)
+}
+
+func IsSentinelReplicaEvent(payload, masterName string) bool {
+	return isReplicaEvent(payload, masterName)
+}
+
+// SentinelReplicasAfterClose returns the number of replicas kept
+// when replicas are discovered after the failover is closed.
+func SentinelReplicasAfterClose(addrs []string) int {
+	d := &sentinelFailover{
+		replicaOpt: &Options{},
+	}
+	_ = d.Close()
+	d.setReplicas(addrs)
+	return len(d.replicas)
+}
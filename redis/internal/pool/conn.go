@@ -18,6 +18,9 @@ type Conn struct {
 
 	Inited bool // 是否已经初始化
 	usedAt atomic.Value
+
+	draining uint32 // atomic
+	reAuth   uint32 // atomic
 }
 
 func NewConn(netConn net.Conn) *Conn {
@@ -43,6 +46,27 @@ func (cn *Conn) SetNetConn(netConn net.Conn) {
 	cn.Rd.Reset(netConn)
 }
 
+// MarkDraining marks the connection to be closed when it is returned
+// to the pool.
+func (cn *Conn) MarkDraining() {
+	atomic.StoreUint32(&cn.draining, 1)
+}
+
+func (cn *Conn) Draining() bool {
+	return atomic.LoadUint32(&cn.draining) == 1
+}
+
+// MarkReAuth marks the connection to be authenticated again before
+// it is used next time.
+func (cn *Conn) MarkReAuth() {
+	atomic.StoreUint32(&cn.reAuth, 1)
+}
+
+// ResetReAuth clears the mark and reports whether it was set.
+func (cn *Conn) ResetReAuth() bool {
+	return atomic.CompareAndSwapUint32(&cn.reAuth, 1, 0)
+}
+
 func (cn *Conn) IsStale(timeout time.Duration) bool {
 	return timeout > 0 && time.Since(cn.UsedAt()) > timeout
 }
@@ -186,7 +186,7 @@ func (p *ConnPool) Get() (*Conn, bool, error) {
 			break
 		}
 
-		if cn.IsStale(p.opt.IdleTimeout) {
+		if cn.IsStale(p.opt.IdleTimeout) || cn.Draining() {
 			p.CloseConn(cn)
 			continue
 		}
@@ -223,6 +223,10 @@ func (p *ConnPool) Put(cn *Conn) error {
 		return p.Remove(cn)
 	}
 	p.freeConnsMu.Lock()
+	if cn.Draining() {
+		p.freeConnsMu.Unlock()
+		return p.Remove(cn)
+	}
 	p.freeConns = append(p.freeConns, cn)
 	p.freeConnsMu.Unlock()
 	<-p.queue
@@ -301,6 +305,49 @@ func (p *ConnPool) Filter(fn func(*Conn) bool) error {
 	return firstErr
 }
 
+// Walk calls fn for every connection including connections in use.
+func (p *ConnPool) Walk(fn func(*Conn)) {
+	p.connsMu.Lock()
+	for _, cn := range p.conns {
+		fn(cn)
+	}
+	p.connsMu.Unlock()
+}
+
+// Drain closes free connections for which fn returns true. Such
+// connections that are in use are closed when they are returned
+// to the pool, so commands in flight are not interrupted.
+func (p *ConnPool) Drain(fn func(*Conn) bool) error {
+	p.connsMu.Lock()
+	for _, cn := range p.conns {
+		if fn(cn) {
+			cn.MarkDraining()
+		}
+	}
+	p.connsMu.Unlock()
+
+	var drained []*Conn
+	p.freeConnsMu.Lock()
+	free := p.freeConns[:0]
+	for _, cn := range p.freeConns {
+		if cn.Draining() {
+			drained = append(drained, cn)
+		} else {
+			free = append(free, cn)
+		}
+	}
+	p.freeConns = free
+	p.freeConnsMu.Unlock()
+
+	var firstErr error
+	for _, cn := range drained {
+		if err := p.CloseConn(cn); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
 func (p *ConnPool) Close() error {
 	if !atomic.CompareAndSwapUint32(&p._closed, 0, 1) {
 		return ErrClosed
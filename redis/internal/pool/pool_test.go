@@ -79,6 +79,62 @@ var _ = Describe("ConnPool", func() {
 			Expect(err).NotTo(HaveOccurred())
 		}
 	})
+
+	It("should drain free conns now and busy conns on Put", func() {
+		// Reaper must not close idle conns during the test.
+		connPool.Close()
+		connPool = pool.NewConnPool(&pool.Options{
+			Dialer:      dummyDialer,
+			PoolSize:    10,
+			PoolTimeout: time.Hour,
+		})
+
+		free, _, err := connPool.Get()
+		Expect(err).NotTo(HaveOccurred())
+		busy, _, err := connPool.Get()
+		Expect(err).NotTo(HaveOccurred())
+		kept, _, err := connPool.Get()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(connPool.Put(free)).NotTo(HaveOccurred())
+		Expect(connPool.Put(kept)).NotTo(HaveOccurred())
+		Expect(connPool.Len()).To(Equal(3))
+		Expect(connPool.FreeLen()).To(Equal(2))
+
+		_ = connPool.Drain(func(cn *pool.Conn) bool {
+			return cn != kept
+		})
+		Expect(connPool.Len()).To(Equal(2))
+		Expect(connPool.FreeLen()).To(Equal(1))
+		Expect(busy.Draining()).To(BeTrue())
+		Expect(kept.Draining()).To(BeFalse())
+
+		Expect(connPool.Put(busy)).NotTo(HaveOccurred())
+		Expect(connPool.Len()).To(Equal(1))
+		Expect(connPool.FreeLen()).To(Equal(1))
+
+		cn, _, err := connPool.Get()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cn).To(Equal(kept))
+		Expect(connPool.Put(cn)).NotTo(HaveOccurred())
+	})
+
+	It("should walk free and busy conns", func() {
+		free, _, err := connPool.Get()
+		Expect(err).NotTo(HaveOccurred())
+		busy, _, err := connPool.Get()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(connPool.Put(free)).NotTo(HaveOccurred())
+
+		connPool.Walk(func(cn *pool.Conn) {
+			cn.MarkReAuth()
+		})
+
+		Expect(free.ResetReAuth()).To(BeTrue())
+		Expect(free.ResetReAuth()).To(BeFalse())
+		Expect(busy.ResetReAuth()).To(BeTrue())
+		Expect(connPool.Put(busy)).NotTo(HaveOccurred())
+	})
 })
 
 var _ = Describe("conns reaper", func() {
@@ -0,0 +1,258 @@
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/internal"
+	"github.com/go-redis/redis/internal/pool"
+)
+
+// KeyspaceEvent is a keyspace notification,
+// see https://redis.io/topics/notifications.
+type KeyspaceEvent struct {
+	DB  int
+	Key string
+	// Event is the command or event name, e.g. set, del or expired.
+	Event string
+}
+
+func (e *KeyspaceEvent) String() string {
+	return fmt.Sprintf("KeyspaceEvent<%d: %s %s>", e.DB, e.Event, e.Key)
+}
+
+// ParseKeyspaceEvent parses a message published to __keyspace@<db>__
+// or __keyevent@<db>__ channel.
+func ParseKeyspaceEvent(msg *Message) (*KeyspaceEvent, error) {
+	var kind string
+	switch {
+	case strings.HasPrefix(msg.Channel, "__keyspace@"):
+		kind = "__keyspace@"
+	case strings.HasPrefix(msg.Channel, "__keyevent@"):
+		kind = "__keyevent@"
+	default:
+		return nil, fmt.Errorf("redis: not a keyspace notification channel: %q", msg.Channel)
+	}
+
+	s := msg.Channel[len(kind):]
+	i := strings.Index(s, "__:")
+	if i == -1 {
+		return nil, fmt.Errorf("redis: invalid keyspace notification channel: %q", msg.Channel)
+	}
+	db, err := strconv.Atoi(s[:i])
+	if err != nil {
+		return nil, fmt.Errorf("redis: invalid keyspace notification channel: %q", msg.Channel)
+	}
+
+	e := &KeyspaceEvent{DB: db}
+	if kind == "__keyspace@" {
+		e.Key, e.Event = s[i+3:], msg.Payload
+	} else {
+		e.Key, e.Event = msg.Payload, s[i+3:]
+	}
+	return e, nil
+}
+
+// KeyspaceOptions configure keyspace notifications subscription.
+type KeyspaceOptions struct {
+	// Value of notify-keyspace-events config set on the servers before
+	// subscribing, e.g. "KA". Keyspace events must include K flag.
+	// Default is to leave servers configuration unchanged.
+	NotifyEvents string
+	// Glob-style pattern of keys to receive events for.
+	// Default is "*".
+	KeyPattern string
+	// Names of events to receive, e.g. "expired". Other events are
+	// dropped. Default is to receive all events.
+	Events []string
+}
+
+func (opt *KeyspaceOptions) init() {
+	if opt.KeyPattern == "" {
+		opt.KeyPattern = "*"
+	}
+}
+
+func (opt *KeyspaceOptions) pattern(db int) string {
+	return "__keyspace@" + strconv.Itoa(db) + "__:" + opt.KeyPattern
+}
+
+// KeyspaceNotifications receives keyspace notifications from one or
+// several servers. It's safe for concurrent use by multiple goroutines.
+type KeyspaceNotifications struct {
+	pubsubs []*PubSub
+	events  map[string]struct{}
+
+	mu     sync.Mutex
+	closed bool
+	exit   chan struct{}
+	wg     sync.WaitGroup
+
+	ch chan *KeyspaceEvent
+}
+
+// KeyspaceNotifications subscribes to keyspace notifications for the
+// keys of the client database.
+func (c *Client) KeyspaceNotifications(opt *KeyspaceOptions) (*KeyspaceNotifications, error) {
+	opt = newKeyspaceOptions(opt)
+	if opt.NotifyEvents != "" {
+		err := c.ConfigSet("notify-keyspace-events", opt.NotifyEvents).Err()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pubsub, err := subscribeKeyspace(c, opt.pattern(c.opt.DB))
+	if err != nil {
+		return nil, err
+	}
+	return newKeyspaceNotifications([]*PubSub{pubsub}, opt), nil
+}
+
+// KeyspaceNotifications subscribes to keyspace notifications on every
+// master. Notifications are published only by the node where the key
+// is changed, so masters added to the cluster later are not covered.
+func (c *ClusterClient) KeyspaceNotifications(opt *KeyspaceOptions) (*KeyspaceNotifications, error) {
+	opt = newKeyspaceOptions(opt)
+	if opt.NotifyEvents != "" {
+		err := c.ConfigSet("notify-keyspace-events", opt.NotifyEvents).Err()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var mu sync.Mutex
+	var pubsubs []*PubSub
+	err := c.ForEachMaster(func(master *Client) error {
+		pubsub, err := subscribeKeyspace(master, opt.pattern(0))
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		pubsubs = append(pubsubs, pubsub)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		for _, pubsub := range pubsubs {
+			_ = pubsub.Close()
+		}
+		return nil, err
+	}
+	return newKeyspaceNotifications(pubsubs, opt), nil
+}
+
+func newKeyspaceOptions(opt *KeyspaceOptions) *KeyspaceOptions {
+	var o KeyspaceOptions
+	if opt != nil {
+		o = *opt
+	}
+	o.init()
+	return &o
+}
+
+// subscribeKeyspace subscribes to the pattern and waits for
+// the confirmation, so no events are missed after it returns.
+func subscribeKeyspace(client *Client, pattern string) (*PubSub, error) {
+	pubsub := client.pubSub()
+	err := pubsub.PSubscribe(pattern)
+	if err == nil {
+		var msgi interface{}
+		msgi, err = pubsub.ReceiveTimeout(client.opt.ReadTimeout)
+		if err == nil {
+			if _, ok := msgi.(*Subscription); !ok {
+				err = fmt.Errorf("redis: unexpected reply to psubscribe: %T", msgi)
+			}
+		}
+	}
+	if err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+	return pubsub, nil
+}
+
+func newKeyspaceNotifications(pubsubs []*PubSub, opt *KeyspaceOptions) *KeyspaceNotifications {
+	n := &KeyspaceNotifications{
+		pubsubs: pubsubs,
+		exit:    make(chan struct{}),
+		ch:      make(chan *KeyspaceEvent, 100),
+	}
+	if len(opt.Events) > 0 {
+		n.events = make(map[string]struct{}, len(opt.Events))
+		for _, event := range opt.Events {
+			n.events[event] = struct{}{}
+		}
+	}
+
+	n.wg.Add(len(pubsubs))
+	for _, pubsub := range pubsubs {
+		go n.receive(pubsub)
+	}
+	go func() {
+		n.wg.Wait()
+		close(n.ch)
+	}()
+	return n
+}
+
+func (n *KeyspaceNotifications) receive(pubsub *PubSub) {
+	defer n.wg.Done()
+
+	for {
+		msg, err := pubsub.receiveMessage(5 * time.Second)
+		if err != nil {
+			if err == pool.ErrClosed {
+				return
+			}
+			internal.Logf("KeyspaceNotifications.receive failed: %s", err)
+			continue
+		}
+
+		e, err := ParseKeyspaceEvent(msg)
+		if err != nil {
+			internal.Logf("KeyspaceNotifications.receive failed: %s", err)
+			continue
+		}
+		if n.events != nil {
+			if _, ok := n.events[e.Event]; !ok {
+				continue
+			}
+		}
+
+		select {
+		case n.ch <- e:
+		case <-n.exit:
+			return
+		}
+	}
+}
+
+// Channel returns a Go channel for receiving events from all servers.
+// The channel is closed together with KeyspaceNotifications.
+func (n *KeyspaceNotifications) Channel() <-chan *KeyspaceEvent {
+	return n.ch
+}
+
+// Close unsubscribes from all servers.
+func (n *KeyspaceNotifications) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.closed {
+		return pool.ErrClosed
+	}
+	n.closed = true
+	close(n.exit)
+
+	var firstErr error
+	for _, pubsub := range n.pubsubs {
+		if err := pubsub.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
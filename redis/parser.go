@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-redis/redis/internal/proto"
@@ -49,6 +50,86 @@ func boolSliceParser(rd *proto.Reader, n int64) (interface{}, error) {
 	return bools, nil
 }
 
+type keyValues struct {
+	key  string
+	vals []string
+}
+
+// Implements proto.MultiBulkParse
+func keyValuesParser(rd *proto.Reader, n int64) (interface{}, error) {
+	if n != 2 {
+		return nil, fmt.Errorf("redis: got %d elements, expected 2", n)
+	}
+
+	key, err := rd.ReadStringReply()
+	if err != nil {
+		return nil, err
+	}
+
+	vals, err := rd.ReadArrayReply(stringSliceParser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keyValues{key: key, vals: vals.([]string)}, nil
+}
+
+type intSlice struct {
+	vals []int64
+	nils []bool
+}
+
+// Implements proto.MultiBulkParse
+func intSliceParser(rd *proto.Reader, n int64) (interface{}, error) {
+	ints := &intSlice{
+		vals: make([]int64, n),
+		nils: make([]bool, n),
+	}
+	for i := int64(0); i < n; i++ {
+		v, err := rd.ReadReply(sliceParser)
+		if err == Nil {
+			ints.nils[i] = true
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch v := v.(type) {
+		case int64:
+			ints.vals[i] = v
+		default:
+			return nil, fmt.Errorf("redis: can't parse int reply: %T", v)
+		}
+	}
+	return ints, nil
+}
+
+type floatSlice struct {
+	vals []float64
+	nils []bool
+}
+
+// Implements proto.MultiBulkParse
+func floatSliceParser(rd *proto.Reader, n int64) (interface{}, error) {
+	floats := &floatSlice{
+		vals: make([]float64, n),
+		nils: make([]bool, n),
+	}
+	for i := int64(0); i < n; i++ {
+		f, err := rd.ReadFloatReply()
+		if err == Nil {
+			floats.nils[i] = true
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		floats.vals[i] = f
+	}
+	return floats, nil
+}
+
 // Implements proto.MultiBulkParse
 func stringSliceParser(rd *proto.Reader, n int64) (interface{}, error) {
 	ss := make([]string, 0, n)
@@ -84,6 +165,19 @@ func stringStringMapParser(rd *proto.Reader, n int64) (interface{}, error) {
 	return m, nil
 }
 
+// Implements proto.MultiBulkParse
+func stringStringMapSliceParser(rd *proto.Reader, n int64) (interface{}, error) {
+	ms := make([]map[string]string, 0, n)
+	for i := int64(0); i < n; i++ {
+		v, err := rd.ReadArrayReply(stringStringMapParser)
+		if err != nil {
+			return nil, err
+		}
+		ms = append(ms, v.(map[string]string))
+	}
+	return ms, nil
+}
+
 // Implements proto.MultiBulkParse
 func stringIntMapParser(rd *proto.Reader, n int64) (interface{}, error) {
 	m := make(map[string]int64, n/2)
@@ -138,6 +232,124 @@ func zSliceParser(rd *proto.Reader, n int64) (interface{}, error) {
 	return zz, nil
 }
 
+// Implements proto.MultiBulkParse
+func zWithKeyParser(rd *proto.Reader, n int64) (interface{}, error) {
+	if n != 3 {
+		return nil, fmt.Errorf("redis: got %d elements, expected 3", n)
+	}
+
+	var z ZWithKey
+	var err error
+
+	z.Key, err = rd.ReadStringReply()
+	if err != nil {
+		return nil, err
+	}
+
+	z.Member, err = rd.ReadStringReply()
+	if err != nil {
+		return nil, err
+	}
+
+	z.Score, err = rd.ReadFloatReply()
+	if err != nil {
+		return nil, err
+	}
+
+	return z, nil
+}
+
+// Implements proto.MultiBulkParse
+func lcsIdxParser(rd *proto.Reader, n int64) (interface{}, error) {
+	var idx LCSIdx
+	for i := int64(0); i < n; i += 2 {
+		key, err := rd.ReadStringReply()
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "matches":
+			v, err := rd.ReadArrayReply(lcsMatchSliceParser)
+			if err != nil {
+				return nil, err
+			}
+			idx.Matches = v.([]LCSMatch)
+		case "len":
+			idx.Len, err = rd.ReadIntReply()
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("redis: unexpected LCS reply key: %q", key)
+		}
+	}
+	return idx, nil
+}
+
+// Implements proto.MultiBulkParse
+func lcsMatchSliceParser(rd *proto.Reader, n int64) (interface{}, error) {
+	matches := make([]LCSMatch, n)
+	for i := int64(0); i < n; i++ {
+		v, err := rd.ReadArrayReply(lcsMatchParser)
+		if err != nil {
+			return nil, err
+		}
+		matches[i] = v.(LCSMatch)
+	}
+	return matches, nil
+}
+
+// Implements proto.MultiBulkParse
+func lcsMatchParser(rd *proto.Reader, n int64) (interface{}, error) {
+	if n != 2 && n != 3 {
+		return nil, fmt.Errorf("redis: got %d elements in LCS match, expected 2 or 3", n)
+	}
+
+	var m LCSMatch
+	v, err := rd.ReadArrayReply(lcsPositionParser)
+	if err != nil {
+		return nil, err
+	}
+	m.A = v.(LCSPosition)
+
+	v, err = rd.ReadArrayReply(lcsPositionParser)
+	if err != nil {
+		return nil, err
+	}
+	m.B = v.(LCSPosition)
+
+	if n == 3 {
+		m.Len, err = rd.ReadIntReply()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Implements proto.MultiBulkParse
+func lcsPositionParser(rd *proto.Reader, n int64) (interface{}, error) {
+	if n != 2 {
+		return nil, fmt.Errorf("redis: got %d elements in LCS position, expected 2", n)
+	}
+
+	var pos LCSPosition
+	var err error
+
+	pos.Start, err = rd.ReadIntReply()
+	if err != nil {
+		return nil, err
+	}
+
+	pos.End, err = rd.ReadIntReply()
+	if err != nil {
+		return nil, err
+	}
+
+	return pos, nil
+}
+
 // Implements proto.MultiBulkParse
 func clusterSlotsParser(rd *proto.Reader, n int64) (interface{}, error) {
 	slots := make([]ClusterSlot, n)
@@ -201,6 +413,109 @@ func clusterSlotsParser(rd *proto.Reader, n int64) (interface{}, error) {
 	return slots, nil
 }
 
+// parseClusterNodes parses CLUSTER NODES reply. Every line has the form
+// <id> <ip:port@cport> <flags> <master> <ping-sent> <pong-recv> <config-epoch> <link-state> <slot> ...
+func parseClusterNodes(s string) ([]ClusterNodeInfo, error) {
+	var nodes []ClusterNodeInfo
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		f := strings.Fields(line)
+		if len(f) < 8 {
+			return nil, fmt.Errorf("redis: got %d fields in cluster nodes line, expected at least 8", len(f))
+		}
+
+		node := ClusterNodeInfo{
+			Id:        f[0],
+			Addr:      clusterNodeAddr(f[1]),
+			Flags:     strings.Split(f[2], ","),
+			LinkState: f[7],
+		}
+		if f[3] != "-" {
+			node.MasterId = f[3]
+		}
+
+		var err error
+		if node.PingSent, err = strconv.ParseInt(f[4], 10, 64); err != nil {
+			return nil, err
+		}
+		if node.PongRecv, err = strconv.ParseInt(f[5], 10, 64); err != nil {
+			return nil, err
+		}
+		if node.ConfigEpoch, err = strconv.ParseInt(f[6], 10, 64); err != nil {
+			return nil, err
+		}
+
+		for _, slot := range f[8:] {
+			if err := parseClusterNodeSlot(&node, slot); err != nil {
+				return nil, err
+			}
+		}
+
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// clusterNodeAddr strips cluster bus port and hostname from the node address.
+func clusterNodeAddr(s string) string {
+	if i := strings.IndexAny(s, "@,"); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
+
+func parseClusterNodeSlot(node *ClusterNodeInfo, s string) error {
+	if strings.HasPrefix(s, "[") {
+		// Open slot: [slot->-id] when migrating or [slot-<-id] when importing.
+		s = strings.Trim(s, "[]")
+		if i := strings.Index(s, "->-"); i >= 0 {
+			slot, err := strconv.Atoi(s[:i])
+			if err != nil {
+				return err
+			}
+			if node.Migrating == nil {
+				node.Migrating = make(map[int]string)
+			}
+			node.Migrating[slot] = s[i+3:]
+			return nil
+		}
+		if i := strings.Index(s, "-<-"); i >= 0 {
+			slot, err := strconv.Atoi(s[:i])
+			if err != nil {
+				return err
+			}
+			if node.Importing == nil {
+				node.Importing = make(map[int]string)
+			}
+			node.Importing[slot] = s[i+3:]
+			return nil
+		}
+		return fmt.Errorf("redis: can't parse cluster nodes slot %q", s)
+	}
+
+	var start, end string
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		start, end = s[:i], s[i+1:]
+	} else {
+		start, end = s, s
+	}
+
+	var r ClusterSlotRange
+	var err error
+	if r.Start, err = strconv.Atoi(start); err != nil {
+		return err
+	}
+	if r.End, err = strconv.Atoi(end); err != nil {
+		return err
+	}
+	node.Slots = append(node.Slots, r)
+	return nil
+}
+
 func newGeoLocationParser(q *GeoRadiusQuery) proto.MultiBulkParse {
 	return func(rd *proto.Reader, n int64) (interface{}, error) {
 		var loc GeoLocation
@@ -392,3 +707,315 @@ func timeParser(rd *proto.Reader, n int64) (interface{}, error) {
 
 	return time.Unix(sec, microsec*1000), nil
 }
+
+// Implements proto.MultiBulkParse
+func aclUserParser(rd *proto.Reader, n int64) (interface{}, error) {
+	var user ACLUser
+	for i := int64(0); i < n; i += 2 {
+		key, err := rd.ReadStringReply()
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := rd.ReadReply(sliceParser)
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "flags":
+			user.Flags = aclStrings(v)
+		case "passwords":
+			user.Passwords = aclStrings(v)
+		case "commands":
+			user.Commands = strings.Join(aclStrings(v), " ")
+		case "keys":
+			user.Keys = aclStrings(v)
+		case "channels":
+			user.Channels = aclStrings(v)
+		}
+	}
+	return user, nil
+}
+
+// aclStrings converts ACL GETUSER value to a slice. Depending on Redis
+// version patterns are replied as an array or as a space separated string.
+func aclStrings(v interface{}) []string {
+	switch v := v.(type) {
+	case []interface{}:
+		ss := make([]string, 0, len(v))
+		for _, vv := range v {
+			if s, ok := vv.(string); ok {
+				ss = append(ss, s)
+			}
+		}
+		return ss
+	case []byte:
+		return strings.Fields(string(v))
+	}
+	return nil
+}
+
+// Implements proto.MultiBulkParse
+func aclLogParser(rd *proto.Reader, n int64) (interface{}, error) {
+	entries := make([]ACLLogEntry, 0, n)
+	for i := int64(0); i < n; i++ {
+		v, err := rd.ReadArrayReply(aclLogEntryParser)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, v.(ACLLogEntry))
+	}
+	return entries, nil
+}
+
+// Implements proto.MultiBulkParse
+func aclLogEntryParser(rd *proto.Reader, n int64) (interface{}, error) {
+	var entry ACLLogEntry
+	for i := int64(0); i < n; i += 2 {
+		key, err := rd.ReadStringReply()
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "count":
+			entry.Count, err = rd.ReadIntReply()
+		case "reason":
+			entry.Reason, err = rd.ReadStringReply()
+		case "context":
+			entry.Context, err = rd.ReadStringReply()
+		case "object":
+			entry.Object, err = rd.ReadStringReply()
+		case "username":
+			entry.Username, err = rd.ReadStringReply()
+		case "age-seconds":
+			var sec float64
+			sec, err = rd.ReadFloatReply()
+			entry.Age = time.Duration(sec * float64(time.Second))
+		case "client-info":
+			entry.ClientInfo, err = rd.ReadStringReply()
+		default:
+			// Skip fields added by newer Redis versions.
+			_, err = rd.ReadReply(sliceParser)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entry, nil
+}
+
+// Implements proto.MultiBulkParse
+func slowLogParser(rd *proto.Reader, n int64) (interface{}, error) {
+	logs := make([]SlowLog, 0, n)
+	for i := int64(0); i < n; i++ {
+		v, err := rd.ReadArrayReply(slowLogEntryParser)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, v.(SlowLog))
+	}
+	return logs, nil
+}
+
+// Implements proto.MultiBulkParse
+func slowLogEntryParser(rd *proto.Reader, n int64) (interface{}, error) {
+	if n < 4 {
+		return nil, fmt.Errorf("redis: got %d elements in slowlog entry, wanted at least 4", n)
+	}
+
+	var log SlowLog
+	var err error
+
+	log.ID, err = rd.ReadIntReply()
+	if err != nil {
+		return nil, err
+	}
+
+	sec, err := rd.ReadIntReply()
+	if err != nil {
+		return nil, err
+	}
+	log.Time = time.Unix(sec, 0)
+
+	us, err := rd.ReadIntReply()
+	if err != nil {
+		return nil, err
+	}
+	log.Duration = time.Duration(us) * time.Microsecond
+
+	v, err := rd.ReadArrayReply(stringSliceParser)
+	if err != nil {
+		return nil, err
+	}
+	log.Args = v.([]string)
+
+	read := int64(4)
+	if n >= 6 {
+		log.ClientAddr, err = rd.ReadStringReply()
+		if err != nil {
+			return nil, err
+		}
+
+		log.ClientName, err = rd.ReadStringReply()
+		if err != nil {
+			return nil, err
+		}
+		read = 6
+	}
+
+	// Skip fields added by newer Redis versions.
+	for i := read; i < n; i++ {
+		_, err = rd.ReadReply(sliceParser)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return log, nil
+}
+
+// Implements proto.MultiBulkParse
+func latencyLatestParser(rd *proto.Reader, n int64) (interface{}, error) {
+	events := make([]LatencyEvent, 0, n)
+	for i := int64(0); i < n; i++ {
+		v, err := rd.ReadArrayReply(latencyEventParser)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, v.(LatencyEvent))
+	}
+	return events, nil
+}
+
+// Implements proto.MultiBulkParse
+func latencyEventParser(rd *proto.Reader, n int64) (interface{}, error) {
+	if n < 4 {
+		return nil, fmt.Errorf("redis: got %d elements in latency event, wanted at least 4", n)
+	}
+
+	var event LatencyEvent
+	var err error
+
+	event.Name, err = rd.ReadStringReply()
+	if err != nil {
+		return nil, err
+	}
+
+	sec, err := rd.ReadIntReply()
+	if err != nil {
+		return nil, err
+	}
+	event.Time = time.Unix(sec, 0)
+
+	ms, err := rd.ReadIntReply()
+	if err != nil {
+		return nil, err
+	}
+	event.Latest = time.Duration(ms) * time.Millisecond
+
+	ms, err = rd.ReadIntReply()
+	if err != nil {
+		return nil, err
+	}
+	event.Max = time.Duration(ms) * time.Millisecond
+
+	for i := int64(4); i < n; i++ {
+		_, err = rd.ReadReply(sliceParser)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return event, nil
+}
+
+// Implements proto.MultiBulkParse
+func latencyHistoryParser(rd *proto.Reader, n int64) (interface{}, error) {
+	samples := make([]LatencySample, 0, n)
+	for i := int64(0); i < n; i++ {
+		v, err := rd.ReadArrayReply(latencySampleParser)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, v.(LatencySample))
+	}
+	return samples, nil
+}
+
+// Implements proto.MultiBulkParse
+func latencySampleParser(rd *proto.Reader, n int64) (interface{}, error) {
+	if n != 2 {
+		return nil, fmt.Errorf("redis: got %d elements in latency sample, wanted 2", n)
+	}
+
+	sec, err := rd.ReadIntReply()
+	if err != nil {
+		return nil, err
+	}
+
+	ms, err := rd.ReadIntReply()
+	if err != nil {
+		return nil, err
+	}
+
+	return LatencySample{
+		Time:    time.Unix(sec, 0),
+		Latency: time.Duration(ms) * time.Millisecond,
+	}, nil
+}
+
+// Implements proto.MultiBulkParse
+func sentinelInfoCacheParser(rd *proto.Reader, n int64) (interface{}, error) {
+	m := make(map[string][]SentinelInfoCache, n/2)
+	for i := int64(0); i < n; i += 2 {
+		name, err := rd.ReadStringReply()
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := rd.ReadArrayReply(sentinelInfoCacheSliceParser)
+		if err != nil {
+			return nil, err
+		}
+
+		m[name] = v.([]SentinelInfoCache)
+	}
+	return m, nil
+}
+
+// Implements proto.MultiBulkParse
+func sentinelInfoCacheSliceParser(rd *proto.Reader, n int64) (interface{}, error) {
+	infos := make([]SentinelInfoCache, 0, n)
+	for i := int64(0); i < n; i++ {
+		v, err := rd.ReadArrayReply(sentinelInfoCacheEntryParser)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, v.(SentinelInfoCache))
+	}
+	return infos, nil
+}
+
+// Implements proto.MultiBulkParse
+func sentinelInfoCacheEntryParser(rd *proto.Reader, n int64) (interface{}, error) {
+	if n != 2 {
+		return nil, fmt.Errorf("redis: got %d elements in sentinel info cache entry, wanted 2", n)
+	}
+
+	ms, err := rd.ReadIntReply()
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := rd.ReadStringReply()
+	if err != nil && err != Nil {
+		return nil, err
+	}
+
+	return SentinelInfoCache{
+		Age:  time.Duration(ms) * time.Millisecond,
+		Info: info,
+	}, nil
+}
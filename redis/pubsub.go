@@ -1,9 +1,11 @@
 package redis
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-redis/redis/internal"
@@ -22,16 +24,31 @@ type PubSub struct {
 	newConn   func([]string) (*pool.Conn, error)
 	closeConn func(*pool.Conn) error
 
-	mu       sync.Mutex
-	cn       *pool.Conn
-	channels map[string]struct{}
-	patterns map[string]struct{}
-	closed   bool
+	mu           sync.Mutex
+	cn           *pool.Conn
+	channels     map[string]struct{}
+	patterns     map[string]struct{}
+	shards       map[string]struct{}
+	closed       bool
+	disconnected bool
+	exit         chan struct{}
+
+	onDisconnect func(error)
+	onReconnect  func()
+	events       pubSubEvents
+	stats        PubSubStats
+
+	lastRecv  int64 // atomic, unix nano of the last reply
+	readStart int64 // atomic, unix nano of the read in progress or 0
+	pingSent  int64 // unix nano of the unanswered health check PING
 
 	cmd *Cmd
 
 	chOnce sync.Once
+	chOpt  *ChannelOptions
+	chExit chan struct{}
 	ch     chan *Message
+	allCh  chan interface{}
 }
 
 func (c *PubSub) conn() (*pool.Conn, error) {
@@ -63,6 +80,25 @@ func (c *PubSub) _conn(channels []string) (*pool.Conn, error) {
 	}
 
 	c.cn = cn
+	atomic.StoreInt64(&c.lastRecv, time.Now().UnixNano())
+	c.pingSent = 0
+
+	if c.disconnected {
+		c.disconnected = false
+		atomic.AddUint32(&c.stats.Reconnects, 1)
+		if len(c.channels) > 0 || len(c.patterns) > 0 || len(c.shards) > 0 {
+			atomic.AddUint32(&c.stats.ReconnectGaps, 1)
+		}
+		if fn := c.onReconnect; fn != nil {
+			c.events.push(fn)
+		}
+	}
+
+	if c.exit == nil && c.opt.PubSubHealthCheckFrequency > 0 {
+		c.exit = make(chan struct{})
+		go c.healthCheck(c.opt.PubSubHealthCheckFrequency, c.exit)
+	}
+
 	return cn, nil
 }
 
@@ -90,6 +126,19 @@ func (c *PubSub) resubscribe(cn *pool.Conn) error {
 			firstErr = err
 		}
 	}
+	if len(c.shards) > 0 {
+		shards := make([]string, len(c.shards))
+		i := 0
+		for channel := range c.shards {
+			shards[i] = channel
+			i++
+		}
+		for _, shards := range channelsBySlot(shards) {
+			if err := c._subscribe(cn, "ssubscribe", shards...); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
 	return firstErr
 }
 
@@ -117,6 +166,18 @@ func (c *PubSub) _releaseConn(cn *pool.Conn, err error) {
 	}
 	if internal.IsBadConn(err, true) {
 		_ = c.closeTheCn()
+		c.disconnect(err)
+	}
+}
+
+// disconnect records that the connection was lost because of the error.
+func (c *PubSub) disconnect(err error) {
+	c.disconnected = true
+	atomic.AddUint32(&c.stats.Disconnects, 1)
+	if fn := c.onDisconnect; fn != nil {
+		c.events.push(func() {
+			fn(err)
+		})
 	}
 }
 
@@ -134,6 +195,12 @@ func (c *PubSub) Close() error {
 		return pool.ErrClosed
 	}
 	c.closed = true
+	if c.exit != nil {
+		close(c.exit)
+	}
+	if c.chExit != nil {
+		close(c.chExit)
+	}
 
 	if c.cn != nil {
 		return c.closeTheCn()
@@ -141,6 +208,118 @@ func (c *PubSub) Close() error {
 	return nil
 }
 
+// OnDisconnect sets a function that is called with the error when
+// the connection is lost. It is called in a separate goroutine, so it
+// can use PubSub.
+func (c *PubSub) OnDisconnect(fn func(err error)) {
+	c.mu.Lock()
+	c.onDisconnect = fn
+	c.mu.Unlock()
+}
+
+// OnReconnect sets a function that is called after PubSub reconnects and
+// resubscribes to the channels and patterns. Messages published while
+// PubSub was disconnected are lost, so fn can be used to resynchronize
+// the state. It is called in a separate goroutine, so it can use PubSub.
+func (c *PubSub) OnReconnect(fn func()) {
+	c.mu.Lock()
+	c.onReconnect = fn
+	c.mu.Unlock()
+}
+
+// PubSubStats contains PubSub connection stats.
+type PubSubStats struct {
+	// Number of times the connection was lost.
+	Disconnects uint32
+	// Number of times PubSub reconnected after the connection was lost.
+	Reconnects uint32
+	// Number of reconnects while subscribed to at least one channel or
+	// pattern. It counts reconnects, not messages: Redis does not buffer
+	// Pub/Sub messages, so any number of messages published during every
+	// such gap, including none, is lost.
+	ReconnectGaps uint32
+	// Number of messages dropped by the PubSub channel, because
+	// the consumer did not keep up.
+	Dropped uint32
+}
+
+// Stats returns PubSub connection stats.
+func (c *PubSub) Stats() *PubSubStats {
+	return &PubSubStats{
+		Disconnects:   atomic.LoadUint32(&c.stats.Disconnects),
+		Reconnects:    atomic.LoadUint32(&c.stats.Reconnects),
+		ReconnectGaps: atomic.LoadUint32(&c.stats.ReconnectGaps),
+		Dropped:       atomic.LoadUint32(&c.stats.Dropped),
+	}
+}
+
+var errPingTimeout = errors.New("redis: pubsub ping timeout")
+
+// healthCheck pings the server when no replies are received during
+// the interval and reconnects if the PING is not answered in time.
+// Replies are read only by the consumer, so the PING is considered
+// unanswered only while the consumer is reading.
+func (c *PubSub) healthCheck(frequency time.Duration, exit chan struct{}) {
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-exit:
+			return
+		}
+
+		c.mu.Lock()
+		c._healthCheck(frequency)
+		c.mu.Unlock()
+	}
+}
+
+func (c *PubSub) _healthCheck(frequency time.Duration) {
+	if c.closed {
+		return
+	}
+
+	now := time.Now().UnixNano()
+	lastRecv := atomic.LoadInt64(&c.lastRecv)
+	if c.pingSent > lastRecv {
+		readStart := atomic.LoadInt64(&c.readStart)
+		if readStart == 0 {
+			// The reply may be waiting for the consumer.
+			return
+		}
+		if readStart < c.pingSent {
+			readStart = c.pingSent
+		}
+		if now-readStart < int64(frequency) {
+			return
+		}
+		// PING is not answered - connection is broken.
+		if c.cn != nil {
+			_ = c.closeTheCn()
+			c.disconnect(errPingTimeout)
+		}
+		c.pingSent = 0
+	} else if c.cn != nil && now-lastRecv < int64(frequency) {
+		return
+	}
+
+	// Reconnects if the connection was lost.
+	cn, err := c._conn(nil)
+	if err != nil {
+		return
+	}
+
+	cmd := NewCmd("ping")
+	cn.SetWriteTimeout(c.opt.WriteTimeout)
+	err = writeCmd(cn, cmd)
+	c._releaseConn(cn, err)
+	if err == nil {
+		c.pingSent = now
+	}
+}
+
 // Subscribe the client to the specified channels. It returns
 // empty subscription if there are no channels.
 func (c *PubSub) Subscribe(channels ...string) error {
@@ -195,6 +374,37 @@ func (c *PubSub) PUnsubscribe(patterns ...string) error {
 	return err
 }
 
+// SSubscribe the client to the specified shard channels. Redis Cluster
+// requires all the channels to belong to the same slot, so most clients
+// should use ClusterClient.SSubscribe.
+func (c *PubSub) SSubscribe(channels ...string) error {
+	c.mu.Lock()
+	err := c.subscribe("ssubscribe", channels...)
+	if c.shards == nil {
+		c.shards = make(map[string]struct{})
+	}
+	for _, channel := range channels {
+		c.shards[channel] = struct{}{}
+	}
+	c.mu.Unlock()
+	return err
+}
+
+// SUnsubscribe the client from the given shard channels, or from all of
+// them if none is given.
+func (c *PubSub) SUnsubscribe(channels ...string) error {
+	c.mu.Lock()
+	err := c.subscribe("sunsubscribe", channels...)
+	for _, channel := range channels {
+		delete(c.shards, channel)
+	}
+	if len(channels) == 0 {
+		c.shards = nil
+	}
+	c.mu.Unlock()
+	return err
+}
+
 func (c *PubSub) subscribe(redisCmd string, channels ...string) error {
 	cn, err := c._conn(channels)
 	if err != nil {
@@ -226,7 +436,8 @@ func (c *PubSub) Ping(payload ...string) error {
 
 // Subscription received after a successful subscription to channel.
 type Subscription struct {
-	// Can be "subscribe", "unsubscribe", "psubscribe" or "punsubscribe".
+	// Can be "subscribe", "unsubscribe", "psubscribe", "punsubscribe",
+	// "ssubscribe" or "sunsubscribe".
 	Kind string
 	// Channel name we have subscribed to.
 	Channel string
@@ -238,7 +449,8 @@ func (m *Subscription) String() string {
 	return fmt.Sprintf("%s: %s", m.Kind, m.Channel)
 }
 
-// Message received as result of a PUBLISH command issued by another client.
+// Message received as result of a PUBLISH or SPUBLISH command issued
+// by another client.
 type Message struct {
 	Channel string
 	Pattern string
@@ -269,13 +481,14 @@ func (c *PubSub) newMessage(reply interface{}) (interface{}, error) {
 		}, nil
 	case []interface{}:
 		switch kind := reply[0].(string); kind {
-		case "subscribe", "unsubscribe", "psubscribe", "punsubscribe":
+		case "subscribe", "unsubscribe", "psubscribe", "punsubscribe",
+			"ssubscribe", "sunsubscribe":
 			return &Subscription{
 				Kind:    kind,
 				Channel: reply[1].(string),
 				Count:   int(reply[2].(int64)),
 			}, nil
-		case "message":
+		case "message", "smessage":
 			return &Message{
 				Channel: reply[1].(string),
 				Payload: reply[2].(string),
@@ -312,11 +525,14 @@ func (c *PubSub) ReceiveTimeout(timeout time.Duration) (interface{}, error) {
 	}
 
 	cn.SetReadTimeout(timeout)
+	atomic.StoreInt64(&c.readStart, time.Now().UnixNano())
 	err = c.cmd.readReply(cn)
+	atomic.StoreInt64(&c.readStart, 0)
 	c.releaseConn(cn, err)
 	if err != nil {
 		return nil, err
 	}
+	atomic.StoreInt64(&c.lastRecv, time.Now().UnixNano())
 
 	return c.newMessage(c.cmd.Val())
 }
@@ -336,6 +552,28 @@ func (c *PubSub) ReceiveMessage() (*Message, error) {
 }
 
 func (c *PubSub) receiveMessage(timeout time.Duration) (*Message, error) {
+	for {
+		msgi, err := c.receive(timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		switch msg := msgi.(type) {
+		case *Subscription:
+			// Ignore.
+		case *Pong:
+			// Ignore.
+		case *Message:
+			return msg, nil
+		default:
+			return nil, fmt.Errorf("redis: unknown message: %T", msgi)
+		}
+	}
+}
+
+// receive is like ReceiveTimeout, but it automatically reconnects
+// and pings the server on network errors.
+func (c *PubSub) receive(timeout time.Duration) (interface{}, error) {
 	var errNum uint
 	for {
 		msgi, err := c.ReceiveTimeout(timeout)
@@ -361,19 +599,42 @@ func (c *PubSub) receiveMessage(timeout time.Duration) (*Message, error) {
 			continue
 		}
 
-		// Reset error number, because we received a message.
-		errNum = 0
+		return msgi, nil
+	}
+}
 
-		switch msg := msgi.(type) {
-		case *Subscription:
-			// Ignore.
-		case *Pong:
-			// Ignore.
-		case *Message:
-			return msg, nil
-		default:
-			return nil, fmt.Errorf("redis: unknown message: %T", msgi)
-		}
+// ChannelPolicy defines what PubSub channel does with a message
+// when the channel buffer is full.
+type ChannelPolicy int
+
+const (
+	// ChannelBlock waits until the consumer receives a message or
+	// ChannelOptions.SendTimeout passes and then drops the message.
+	// Receiving from Redis is blocked meanwhile.
+	ChannelBlock ChannelPolicy = iota
+	// ChannelDropNewest drops the received message.
+	ChannelDropNewest
+	// ChannelDropOldest drops the oldest message in the buffer to make
+	// room for the received message.
+	ChannelDropOldest
+)
+
+// ChannelOptions are used to configure a channel returned
+// by PubSub.ChannelWithOptions and PubSub.ChannelWithSubscriptions.
+type ChannelOptions struct {
+	// Size of the channel buffer.
+	// Default is 100 messages.
+	Size int
+	// Maximum time to wait for the consumer with ChannelBlock policy.
+	// Default is to wait forever.
+	SendTimeout time.Duration
+	// Default is ChannelBlock.
+	Policy ChannelPolicy
+}
+
+func (opt *ChannelOptions) init() {
+	if opt.Size <= 0 {
+		opt.Size = 100
 	}
 }
 
@@ -381,21 +642,258 @@ func (c *PubSub) receiveMessage(timeout time.Duration) (*Message, error) {
 // The channel is closed with PubSub. Receive or ReceiveMessage APIs
 // can not be used after channel is created.
 func (c *PubSub) Channel() <-chan *Message {
+	return c.ChannelWithOptions(nil)
+}
+
+// ChannelWithOptions is like Channel, but allows to configure channel
+// buffer and what to do when consumer does not keep up. Dropped messages
+// are counted in PubSubStats. Only one channel can be created per PubSub:
+// subsequent calls return the same channel and opt is ignored. A closed
+// channel is returned if the PubSub already has a channel created with
+// ChannelWithSubscriptions.
+func (c *PubSub) ChannelWithOptions(opt *ChannelOptions) <-chan *Message {
 	c.chOnce.Do(func() {
-		c.ch = make(chan *Message, 100)
-		go func() {
-			for {
-				msg, err := c.ReceiveMessage()
-				if err != nil {
-					if err == pool.ErrClosed {
-						break
-					}
-					continue
+		c.chOpt = channelOptions(opt)
+		c.ch = make(chan *Message, c.chOpt.Size)
+		exit := c.channelExit()
+		ch := pubSubChannel{
+			opt:  c.chOpt,
+			exit: exit,
+			send: func(msg interface{}, timeout <-chan time.Time) bool {
+				select {
+				case c.ch <- msg.(*Message):
+					return true
+				case <-timeout:
+					return false
+				case <-exit:
+					return false
 				}
-				c.ch <- msg
-			}
+			},
+			trySend: func(msg interface{}) bool {
+				select {
+				case c.ch <- msg.(*Message):
+					return true
+				default:
+					return false
+				}
+			},
+			drop: func() bool {
+				select {
+				case <-c.ch:
+					return true
+				default:
+					return false
+				}
+			},
+		}
+		go func() {
+			c.channelLoop(&ch, false)
 			close(c.ch)
 		}()
 	})
+	if c.ch == nil {
+		internal.Logf("redis: PubSub.Channel can't be used with PubSub.ChannelWithSubscriptions")
+		ch := make(chan *Message)
+		close(ch)
+		return ch
+	}
+	c.checkChannelOptions(opt)
 	return c.ch
 }
+
+// ChannelWithSubscriptions is like ChannelWithOptions, but the channel
+// also delivers *Subscription and *Pong values. They are subject to
+// the same buffering policy as messages and counted as dropped too.
+// A closed channel is returned if the PubSub already has a channel
+// created with Channel or ChannelWithOptions.
+func (c *PubSub) ChannelWithSubscriptions(opt *ChannelOptions) <-chan interface{} {
+	c.chOnce.Do(func() {
+		c.chOpt = channelOptions(opt)
+		c.allCh = make(chan interface{}, c.chOpt.Size)
+		exit := c.channelExit()
+		ch := pubSubChannel{
+			opt:  c.chOpt,
+			exit: exit,
+			send: func(msg interface{}, timeout <-chan time.Time) bool {
+				select {
+				case c.allCh <- msg:
+					return true
+				case <-timeout:
+					return false
+				case <-exit:
+					return false
+				}
+			},
+			trySend: func(msg interface{}) bool {
+				select {
+				case c.allCh <- msg:
+					return true
+				default:
+					return false
+				}
+			},
+			drop: func() bool {
+				select {
+				case <-c.allCh:
+					return true
+				default:
+					return false
+				}
+			},
+		}
+		go func() {
+			c.channelLoop(&ch, true)
+			close(c.allCh)
+		}()
+	})
+	if c.allCh == nil {
+		internal.Logf("redis: PubSub.ChannelWithSubscriptions can't be used with PubSub.Channel")
+		ch := make(chan interface{})
+		close(ch)
+		return ch
+	}
+	c.checkChannelOptions(opt)
+	return c.allCh
+}
+
+// channelExit returns a Go channel that is closed with PubSub,
+// so a blocked send to the PubSub channel does not leak the goroutine.
+func (c *PubSub) channelExit() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.chExit = make(chan struct{})
+	if c.closed {
+		close(c.chExit)
+	}
+	return c.chExit
+}
+
+// checkChannelOptions logs options that differ from the options
+// the channel was created with, because they are ignored.
+func (c *PubSub) checkChannelOptions(opt *ChannelOptions) {
+	if opt != nil && *channelOptions(opt) != *c.chOpt {
+		internal.Logf("redis: PubSub channel is already created with different options")
+	}
+}
+
+func channelOptions(opt *ChannelOptions) *ChannelOptions {
+	if opt == nil {
+		opt = new(ChannelOptions)
+	} else {
+		// Copy options, so they can't be changed while in use.
+		o := *opt
+		opt = &o
+	}
+	opt.init()
+	return opt
+}
+
+// pubSubChannel sends messages to a typed Go channel.
+type pubSubChannel struct {
+	opt *ChannelOptions
+	// exit is closed with PubSub.
+	exit <-chan struct{}
+	// send blocks until the message is sent, timeout fires or exit
+	// is closed. Nil timeout waits forever.
+	send func(msg interface{}, timeout <-chan time.Time) bool
+	// trySend sends the message if there is room in the channel buffer.
+	trySend func(msg interface{}) bool
+	// drop removes the oldest message from the channel buffer.
+	drop func() bool
+}
+
+func (c *PubSub) channelLoop(ch *pubSubChannel, withSubscriptions bool) {
+	for {
+		msgi, err := c.receive(5 * time.Second)
+		if err != nil {
+			if err == pool.ErrClosed {
+				return
+			}
+			continue
+		}
+
+		switch msgi.(type) {
+		case *Message:
+			c.sendToChannel(ch, msgi)
+		case *Subscription, *Pong:
+			if withSubscriptions {
+				c.sendToChannel(ch, msgi)
+			}
+		}
+	}
+}
+
+func (c *PubSub) sendToChannel(ch *pubSubChannel, msg interface{}) {
+	if ch.trySend(msg) {
+		return
+	}
+
+	switch ch.opt.Policy {
+	case ChannelDropNewest:
+		// Drop the message.
+	case ChannelDropOldest:
+		for ch.drop() {
+			atomic.AddUint32(&c.stats.Dropped, 1)
+			if ch.trySend(msg) {
+				return
+			}
+		}
+		// Consumer emptied the buffer concurrently.
+		if ch.trySend(msg) {
+			return
+		}
+	default:
+		var timeout <-chan time.Time
+		if ch.opt.SendTimeout > 0 {
+			timer := time.NewTimer(ch.opt.SendTimeout)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		if ch.send(msg, timeout) {
+			return
+		}
+
+		select {
+		case <-ch.exit:
+			// PubSub is closed, so the message is not dropped
+			// because of the consumer.
+			return
+		default:
+		}
+	}
+	atomic.AddUint32(&c.stats.Dropped, 1)
+}
+
+// pubSubEvents runs PubSub callbacks one by one in a separate goroutine,
+// so callbacks are not called with PubSub mutex held.
+type pubSubEvents struct {
+	mu      sync.Mutex
+	queue   []func()
+	running bool
+}
+
+func (e *pubSubEvents) push(fn func()) {
+	e.mu.Lock()
+	e.queue = append(e.queue, fn)
+	if !e.running {
+		e.running = true
+		go e.run()
+	}
+	e.mu.Unlock()
+}
+
+func (e *pubSubEvents) run() {
+	for {
+		e.mu.Lock()
+		if len(e.queue) == 0 {
+			e.running = false
+			e.mu.Unlock()
+			return
+		}
+		fn := e.queue[0]
+		e.queue = e.queue[1:]
+		e.mu.Unlock()
+
+		fn()
+	}
+}
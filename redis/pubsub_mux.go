@@ -0,0 +1,150 @@
+package redis
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/internal"
+	"github.com/go-redis/redis/internal/pool"
+)
+
+// MessageHandler handles a message received by PubSubMux.
+type MessageHandler func(msg *Message)
+
+// PubSubMux dispatches PubSub messages to handlers registered for
+// channels and patterns. PubSub is subscribed to the channels and
+// patterns when handlers are added and unsubscribed when handlers are
+// removed. It's safe for concurrent use by multiple goroutines.
+//
+// Redis matches messages against patterns the same way as PSUBSCRIBE
+// does and reports the matched pattern, so a message published to
+// a channel that matches both a channel and a pattern handler
+// is handled by both of them.
+type PubSubMux struct {
+	pubsub *PubSub
+
+	mu       sync.RWMutex
+	channels map[string]MessageHandler
+	patterns map[string]MessageHandler
+
+	sem chan struct{}
+	wg  sync.WaitGroup
+}
+
+// Mux returns a dispatcher that runs at most concurrency handlers
+// at the same time. Messages are handled one by one in the order they
+// are received when concurrency is 1 or less. Receive, ReceiveMessage
+// and Channel APIs can not be used together with the dispatcher.
+func (c *PubSub) Mux(concurrency int) *PubSubMux {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &PubSubMux{
+		pubsub:   c,
+		channels: make(map[string]MessageHandler),
+		patterns: make(map[string]MessageHandler),
+		sem:      make(chan struct{}, concurrency),
+	}
+}
+
+// Handle registers the handler for the channel and subscribes to it.
+// Handler registered earlier for the same channel is replaced.
+func (m *PubSubMux) Handle(channel string, handler MessageHandler) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.channels[channel]
+	m.channels[channel] = handler
+	if ok {
+		return nil
+	}
+	return m.pubsub.Subscribe(channel)
+}
+
+// HandlePattern registers the handler for the glob-style pattern and
+// subscribes to it. Handler registered earlier for the same pattern
+// is replaced.
+func (m *PubSubMux) HandlePattern(pattern string, handler MessageHandler) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.patterns[pattern]
+	m.patterns[pattern] = handler
+	if ok {
+		return nil
+	}
+	return m.pubsub.PSubscribe(pattern)
+}
+
+// Remove removes the handler for the channel and unsubscribes from it.
+func (m *PubSubMux) Remove(channel string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.channels[channel]; !ok {
+		return nil
+	}
+	delete(m.channels, channel)
+	return m.pubsub.Unsubscribe(channel)
+}
+
+// RemovePattern removes the handler for the pattern and unsubscribes
+// from it.
+func (m *PubSubMux) RemovePattern(pattern string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.patterns[pattern]; !ok {
+		return nil
+	}
+	delete(m.patterns, pattern)
+	return m.pubsub.PUnsubscribe(pattern)
+}
+
+func (m *PubSubMux) handler(msg *Message) MessageHandler {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if msg.Pattern != "" {
+		return m.patterns[msg.Pattern]
+	}
+	return m.channels[msg.Channel]
+}
+
+// Serve receives messages and dispatches them to the handlers until
+// PubSub is closed. It waits for running handlers before returning.
+// Messages received after their handler was removed are dropped.
+func (m *PubSubMux) Serve() error {
+	defer m.wg.Wait()
+
+	for {
+		msg, err := m.pubsub.receiveMessage(5 * time.Second)
+		if err != nil {
+			if err == pool.ErrClosed {
+				return nil
+			}
+			internal.Logf("PubSubMux.Serve failed: %s", err)
+			continue
+		}
+
+		handler := m.handler(msg)
+		if handler == nil {
+			continue
+		}
+
+		if cap(m.sem) == 1 {
+			handler(msg)
+			continue
+		}
+
+		m.sem <- struct{}{}
+		m.wg.Add(1)
+		go func() {
+			defer func() {
+				<-m.sem
+				m.wg.Done()
+			}()
+			handler(msg)
+		}()
+	}
+}
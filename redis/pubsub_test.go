@@ -4,6 +4,7 @@ import (
 	"io"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-redis/redis"
@@ -194,6 +195,43 @@ var _ = Describe("PubSub", func() {
 		Expect(stats.Misses).To(Equal(uint32(2)))
 	})
 
+	It("should pub/sub shard channels", func() {
+		pubsub := client.SSubscribe("mychannel")
+		defer pubsub.Close()
+
+		{
+			msgi, err := pubsub.ReceiveTimeout(time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			subscr := msgi.(*redis.Subscription)
+			Expect(subscr.Kind).To(Equal("ssubscribe"))
+			Expect(subscr.Channel).To(Equal("mychannel"))
+			Expect(subscr.Count).To(Equal(1))
+		}
+
+		n, err := client.SPublish("mychannel", "hello").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(int64(1)))
+
+		{
+			msgi, err := pubsub.ReceiveTimeout(time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			msg := msgi.(*redis.Message)
+			Expect(msg.Channel).To(Equal("mychannel"))
+			Expect(msg.Payload).To(Equal("hello"))
+		}
+
+		Expect(pubsub.SUnsubscribe("mychannel")).NotTo(HaveOccurred())
+
+		{
+			msgi, err := pubsub.ReceiveTimeout(time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			subscr := msgi.(*redis.Subscription)
+			Expect(subscr.Kind).To(Equal("sunsubscribe"))
+			Expect(subscr.Channel).To(Equal("mychannel"))
+			Expect(subscr.Count).To(Equal(0))
+		}
+	})
+
 	It("should ping/pong", func() {
 		pubsub := client.Subscribe("mychannel")
 		defer pubsub.Close()
@@ -366,6 +404,108 @@ var _ = Describe("PubSub", func() {
 		expectReceiveMessageOnError(pubsub)
 	})
 
+	It("calls OnDisconnect and OnReconnect", func() {
+		pubsub := client.Subscribe("mychannel")
+		defer pubsub.Close()
+
+		disconnected := make(chan error, 1)
+		pubsub.OnDisconnect(func(err error) {
+			disconnected <- err
+		})
+		reconnected := make(chan bool, 1)
+		pubsub.OnReconnect(func() {
+			// Callbacks are allowed to use PubSub.
+			Expect(pubsub.Ping()).NotTo(HaveOccurred())
+			reconnected <- true
+		})
+
+		subscr, err := pubsub.ReceiveTimeout(time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subscr).To(BeAssignableToTypeOf(&redis.Subscription{}))
+
+		expectReceiveMessageOnError(pubsub)
+
+		Eventually(disconnected).Should(Receive(Equal(io.EOF)))
+		Eventually(reconnected).Should(Receive())
+		Expect(pubsub.Stats()).To(Equal(&redis.PubSubStats{
+			Disconnects:   1,
+			Reconnects:    1,
+			ReconnectGaps: 1,
+		}))
+	})
+
+	It("reconnects on failed health check", func() {
+		opt := redisOptions()
+		opt.PubSubHealthCheckFrequency = 100 * time.Millisecond
+		client := redis.NewClient(opt)
+		defer client.Close()
+
+		pubsub := client.Subscribe("mychannel")
+		defer pubsub.Close()
+
+		reconnected := make(chan bool, 1)
+		pubsub.OnReconnect(func() {
+			select {
+			case reconnected <- true:
+			default:
+			}
+		})
+
+		subscr, err := pubsub.ReceiveTimeout(time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subscr).To(BeAssignableToTypeOf(&redis.Subscription{}))
+
+		// Health check fails to write PING and reconnects in background.
+		pubsub.SetNetConn(&badConn{
+			readErr:  io.EOF,
+			writeErr: io.EOF,
+		})
+		Eventually(reconnected, time.Second).Should(Receive())
+		Expect(pubsub.Stats().Reconnects).To(BeNumerically(">=", 1))
+
+		Eventually(func() error {
+			err := client.Publish("mychannel", "hello").Err()
+			if err != nil {
+				return err
+			}
+			for {
+				msgi, err := pubsub.ReceiveTimeout(time.Second)
+				if err != nil {
+					return err
+				}
+				if msg, ok := msgi.(*redis.Message); ok {
+					Expect(msg.Payload).To(Equal("hello"))
+					return nil
+				}
+			}
+		}, 5*time.Second).ShouldNot(HaveOccurred())
+	})
+
+	It("does not reconnect when consumer is not receiving", func() {
+		opt := redisOptions()
+		opt.PubSubHealthCheckFrequency = 100 * time.Millisecond
+		client := redis.NewClient(opt)
+		defer client.Close()
+
+		pubsub := client.Subscribe("mychannel")
+		defer pubsub.Close()
+
+		subscr, err := pubsub.ReceiveTimeout(time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subscr).To(BeAssignableToTypeOf(&redis.Subscription{}))
+
+		err = client.Publish("mychannel", "hello").Err()
+		Expect(err).NotTo(HaveOccurred())
+
+		// Health check PINGs are not answered until the consumer reads.
+		time.Sleep(500 * time.Millisecond)
+
+		msg, err := pubsub.ReceiveMessage()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(msg.Payload).To(Equal("hello"))
+		Expect(pubsub.Stats().Disconnects).To(BeZero())
+	})
+
 	It("should return on Close", func() {
 		pubsub := client.Subscribe("mychannel")
 		defer pubsub.Close()
@@ -440,4 +580,269 @@ var _ = Describe("PubSub", func() {
 		Expect(msg.Channel).To(Equal("mychannel"))
 		Expect(msg.Payload).To(Equal(string(bigVal)))
 	})
+
+	expectChannelPolicy := func(opt *redis.ChannelOptions, wanted string) {
+		pubsub := client.Subscribe("mychannel")
+		defer pubsub.Close()
+
+		_, err := pubsub.ReceiveTimeout(time.Second)
+		Expect(err).NotTo(HaveOccurred())
+
+		ch := pubsub.ChannelWithOptions(opt)
+		for _, payload := range []string{"1", "2", "3"} {
+			err := client.Publish("mychannel", payload).Err()
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Eventually(func() uint32 {
+			return pubsub.Stats().Dropped
+		}).Should(Equal(uint32(2)))
+
+		var msg *redis.Message
+		Eventually(ch).Should(Receive(&msg))
+		Expect(msg.Payload).To(Equal(wanted))
+		Consistently(ch).ShouldNot(Receive())
+	}
+
+	It("drops newest messages when channel is full", func() {
+		expectChannelPolicy(&redis.ChannelOptions{
+			Size:   1,
+			Policy: redis.ChannelDropNewest,
+		}, "1")
+	})
+
+	It("drops oldest messages when channel is full", func() {
+		expectChannelPolicy(&redis.ChannelOptions{
+			Size:   1,
+			Policy: redis.ChannelDropOldest,
+		}, "3")
+	})
+
+	It("drops messages after send timeout", func() {
+		expectChannelPolicy(&redis.ChannelOptions{
+			Size:        1,
+			SendTimeout: 100 * time.Millisecond,
+		}, "1")
+	})
+
+	It("does not block closed PubSub on full channel", func() {
+		pubsub := client.Subscribe("mychannel")
+
+		_, err := pubsub.ReceiveTimeout(time.Second)
+		Expect(err).NotTo(HaveOccurred())
+
+		ch := pubsub.ChannelWithOptions(&redis.ChannelOptions{Size: 1})
+		for _, payload := range []string{"1", "2"} {
+			err := client.Publish("mychannel", payload).Err()
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Eventually(func() int {
+			return len(ch)
+		}).Should(Equal(1))
+
+		Expect(pubsub.Close()).NotTo(HaveOccurred())
+
+		var msg *redis.Message
+		Expect(ch).To(Receive(&msg))
+		Expect(msg.Payload).To(Equal("1"))
+		Eventually(ch).Should(BeClosed())
+		Expect(pubsub.Stats().Dropped).To(Equal(uint32(0)))
+	})
+
+	It("returns closed channel when channel types are mixed", func() {
+		pubsub := client.Subscribe()
+		defer pubsub.Close()
+
+		ch := pubsub.Channel()
+		Expect(pubsub.ChannelWithOptions(nil)).To(Equal(ch))
+		Expect(pubsub.ChannelWithOptions(&redis.ChannelOptions{Size: 1})).To(Equal(ch))
+		Expect(pubsub.ChannelWithSubscriptions(nil)).To(BeClosed())
+		Expect(ch).NotTo(BeClosed())
+	})
+
+	It("delivers subscriptions and pongs", func() {
+		pubsub := client.Subscribe("mychannel")
+		defer pubsub.Close()
+
+		ch := pubsub.ChannelWithSubscriptions(nil)
+
+		var msgi interface{}
+		Eventually(ch).Should(Receive(&msgi))
+		Expect(msgi).To(Equal(&redis.Subscription{
+			Kind:    "subscribe",
+			Channel: "mychannel",
+			Count:   1,
+		}))
+
+		err := client.Publish("mychannel", "hello").Err()
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(ch).Should(Receive(&msgi))
+		Expect(msgi).To(Equal(&redis.Message{
+			Channel: "mychannel",
+			Payload: "hello",
+		}))
+
+		Expect(pubsub.Ping("hi")).NotTo(HaveOccurred())
+
+		Eventually(ch).Should(Receive(&msgi))
+		Expect(msgi).To(Equal(&redis.Pong{
+			Payload: "hi",
+		}))
+	})
+
+	It("dispatches messages to handlers", func() {
+		pubsub := client.Subscribe()
+		mux := pubsub.Mux(1)
+
+		received := make(chan *redis.Message, 10)
+		handler := func(msg *redis.Message) {
+			received <- msg
+		}
+		Expect(mux.Handle("mychannel", handler)).NotTo(HaveOccurred())
+		Expect(mux.HandlePattern("news.*", handler)).NotTo(HaveOccurred())
+
+		done := make(chan error, 1)
+		go func() {
+			done <- mux.Serve()
+		}()
+
+		Eventually(func() map[string]int64 {
+			return client.PubSubNumSub("mychannel").Val()
+		}).Should(Equal(map[string]int64{"mychannel": 1}))
+		Eventually(func() int64 {
+			return client.PubSubNumPat().Val()
+		}).Should(Equal(int64(1)))
+
+		Expect(client.Publish("mychannel", "hello").Err()).NotTo(HaveOccurred())
+		Expect(client.Publish("news.tech", "world").Err()).NotTo(HaveOccurred())
+		Expect(client.Publish("other", "ignored").Err()).NotTo(HaveOccurred())
+
+		var msg *redis.Message
+		Eventually(received).Should(Receive(&msg))
+		Expect(msg).To(Equal(&redis.Message{
+			Channel: "mychannel",
+			Payload: "hello",
+		}))
+		Eventually(received).Should(Receive(&msg))
+		Expect(msg).To(Equal(&redis.Message{
+			Channel: "news.tech",
+			Pattern: "news.*",
+			Payload: "world",
+		}))
+		Consistently(received).ShouldNot(Receive())
+
+		Expect(mux.Remove("mychannel")).NotTo(HaveOccurred())
+		Expect(mux.RemovePattern("news.*")).NotTo(HaveOccurred())
+		Eventually(func() map[string]int64 {
+			return client.PubSubNumSub("mychannel").Val()
+		}).Should(Equal(map[string]int64{"mychannel": 0}))
+		Eventually(func() int64 {
+			return client.PubSubNumPat().Val()
+		}).Should(Equal(int64(0)))
+
+		Expect(pubsub.Close()).NotTo(HaveOccurred())
+		Eventually(done).Should(Receive(BeNil()))
+	})
+
+	It("runs handlers with bounded concurrency", func() {
+		pubsub := client.Subscribe()
+		defer pubsub.Close()
+		mux := pubsub.Mux(2)
+
+		var running, maxRunning, handled int32
+		err := mux.Handle("mychannel", func(msg *redis.Message) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+					break
+				}
+			}
+			time.Sleep(50 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			atomic.AddInt32(&handled, 1)
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		go mux.Serve()
+
+		Eventually(func() map[string]int64 {
+			return client.PubSubNumSub("mychannel").Val()
+		}).Should(Equal(map[string]int64{"mychannel": 1}))
+
+		for i := 0; i < 10; i++ {
+			Expect(client.Publish("mychannel", "hello").Err()).NotTo(HaveOccurred())
+		}
+
+		Eventually(func() int32 {
+			return atomic.LoadInt32(&handled)
+		}).Should(Equal(int32(10)))
+		Expect(atomic.LoadInt32(&maxRunning)).To(Equal(int32(2)))
+	})
+
+	It("receives keyspace notifications", func() {
+		defer client.ConfigSet("notify-keyspace-events", "")
+
+		notifications, err := client.KeyspaceNotifications(&redis.KeyspaceOptions{
+			NotifyEvents: "KA",
+			KeyPattern:   "key*",
+			Events:       []string{"set", "expired"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer notifications.Close()
+
+		Expect(client.Set("key1", "value", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Del("key1").Err()).NotTo(HaveOccurred())
+		Expect(client.Set("other", "value", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Set("key2", "value", time.Millisecond).Err()).NotTo(HaveOccurred())
+
+		ch := notifications.Channel()
+		Eventually(ch).Should(Receive(Equal(&redis.KeyspaceEvent{
+			DB:    15,
+			Key:   "key1",
+			Event: "set",
+		})))
+		Eventually(ch).Should(Receive(Equal(&redis.KeyspaceEvent{
+			DB:    15,
+			Key:   "key2",
+			Event: "set",
+		})))
+		Eventually(ch).Should(Receive(Equal(&redis.KeyspaceEvent{
+			DB:    15,
+			Key:   "key2",
+			Event: "expired",
+		})))
+
+		Expect(notifications.Close()).NotTo(HaveOccurred())
+		Eventually(ch).Should(BeClosed())
+	})
+})
+
+var _ = Describe("ParseKeyspaceEvent", func() {
+	It("parses keyspace channel", func() {
+		e, err := redis.ParseKeyspaceEvent(&redis.Message{
+			Channel: "__keyspace@3__:foo:bar",
+			Payload: "expired",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e).To(Equal(&redis.KeyspaceEvent{DB: 3, Key: "foo:bar", Event: "expired"}))
+	})
+
+	It("parses keyevent channel", func() {
+		e, err := redis.ParseKeyspaceEvent(&redis.Message{
+			Channel: "__keyevent@0__:del",
+			Payload: "foo",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e).To(Equal(&redis.KeyspaceEvent{DB: 0, Key: "foo", Event: "del"}))
+	})
+
+	It("rejects other channels", func() {
+		_, err := redis.ParseKeyspaceEvent(&redis.Message{Channel: "mychannel"})
+		Expect(err).To(HaveOccurred())
+
+		_, err = redis.ParseKeyspaceEvent(&redis.Message{Channel: "__keyspace@x__:foo"})
+		Expect(err).To(HaveOccurred())
+	})
 })
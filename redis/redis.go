@@ -31,7 +31,8 @@ type baseClient struct {
 	processPipeline   func([]Cmder) error
 	processTxPipeline func([]Cmder) error
 
-	onClose func() error // hook called when client is closed
+	onClose  func() error // hook called when client is closed
+	onReAuth func()       // hook called when client re-authenticates connections
 }
 
 func (c *baseClient) init() {
@@ -71,6 +72,11 @@ func (c *baseClient) getConn() (*pool.Conn, bool, error) {
 			_ = c.connPool.Remove(cn) // 如果初始化出错，则从连接池里面去除
 			return nil, false, err
 		}
+	} else if cn.ResetReAuth() {
+		if err := c.reAuthConn(cn); err != nil {
+			_ = c.connPool.Remove(cn)
+			return nil, false, err
+		}
 	}
 
 	return cn, isNew, nil
@@ -90,16 +96,25 @@ func (c *baseClient) initConn(cn *pool.Conn) error {
 	cn.Inited = true
 
 	if c.opt.Password == "" &&
+		c.opt.Username == "" &&
+		c.opt.CredentialsProvider == nil &&
 		c.opt.DB == 0 &&
 		!c.opt.readOnly &&
 		c.opt.OnConnect == nil {
 		return nil
 	}
 
+	username, password, err := c.credentials()
+	if err != nil {
+		return err
+	}
+
 	conn := newConn(c.opt, cn)
-	_, err := conn.Pipelined(func(pipe Pipeliner) error {
-		if c.opt.Password != "" {
-			pipe.Auth(c.opt.Password)
+	_, err = conn.Pipelined(func(pipe Pipeliner) error {
+		if username != "" {
+			pipe.AuthACL(username, password)
+		} else if password != "" {
+			pipe.Auth(password)
 		}
 
 		if c.opt.DB > 0 {
@@ -122,6 +137,80 @@ func (c *baseClient) initConn(cn *pool.Conn) error {
 	return nil
 }
 
+func (c *baseClient) credentials() (username, password string, err error) {
+	if c.opt.CredentialsProvider != nil {
+		return c.opt.CredentialsProvider()
+	}
+	return c.opt.Username, c.opt.Password, nil
+}
+
+func (c *baseClient) reAuthConn(cn *pool.Conn) error {
+	username, password, err := c.credentials()
+	if err != nil {
+		return err
+	}
+
+	conn := newConn(c.opt, cn)
+	if username != "" {
+		return conn.AuthACL(username, password).Err()
+	}
+	if password != "" {
+		return conn.Auth(password).Err()
+	}
+	return nil
+}
+
+// ReAuth authenticates pooled connections again with credentials
+// returned by CredentialsProvider, e.g. after the password is rotated.
+// Free connections are authenticated before they are used next time
+// and connections in use after they are returned to the pool.
+func (c *baseClient) ReAuth() {
+	if p, ok := c.connPool.(*pool.ConnPool); ok {
+		p.Walk(func(cn *pool.Conn) {
+			cn.MarkReAuth()
+		})
+	}
+	if c.onReAuth != nil {
+		c.onReAuth()
+	}
+}
+
+// ProcessNoReply sends the commands between CLIENT REPLY OFF and
+// CLIENT REPLY ON, so Redis does not reply to them. It is useful to
+// send many commands whose results are not needed, e.g. to load data.
+// Commands report only network errors and errors of Redis itself are
+// not returned. Requires Redis 3.2.
+//
+// Commands are written directly to a pooled connection, so they bypass
+// WrapProcess and WrapProcessPipeline hooks, e.g. FailoverClient
+// does not switch to the new master on READONLY errors.
+func (c *baseClient) ProcessNoReply(cmds ...Cmder) error {
+	cn, _, err := c.getConn()
+	if err != nil {
+		setCmdsErr(cmds, err)
+		return err
+	}
+
+	on := NewStatusCmd("client", "reply", "on")
+	all := make([]Cmder, 0, len(cmds)+2)
+	all = append(all, NewStatusCmd("client", "reply", "off"))
+	all = append(all, cmds...)
+	all = append(all, on)
+
+	cn.SetWriteTimeout(c.opt.WriteTimeout)
+	err = writeCmd(cn, all...)
+	if err == nil {
+		cn.SetReadTimeout(c.opt.ReadTimeout)
+		err = on.readReply(cn)
+	}
+	c.releaseConn(cn, err)
+
+	if err != nil {
+		setCmdsErr(cmds, err)
+	}
+	return err
+}
+
 // WrapProcess wraps function that processes Redis commands.
 func (c *baseClient) WrapProcess(fn func(oldProcess func(cmd Cmder) error) func(cmd Cmder) error) {
 	c.process = fn(c.process)
@@ -139,7 +228,6 @@ func (c *baseClient) defaultProcess(cmd Cmder) error {
 		}
 
 		cn, _, err := c.getConn() // 从连接池里面获取一个连接
-		fmt.Println(cn.RemoteAddr())
 		if err != nil {
 			cmd.setErr(err)
 			if internal.IsRetryableError(err, true) {
@@ -457,6 +545,16 @@ func (c *Client) PSubscribe(channels ...string) *PubSub {
 	return pubsub
 }
 
+// SSubscribe subscribes the client to the specified shard channels.
+// Channels can be omitted to create empty subscription.
+func (c *Client) SSubscribe(channels ...string) *PubSub {
+	pubsub := c.pubSub()
+	if len(channels) > 0 {
+		_ = pubsub.SSubscribe(channels...)
+	}
+	return pubsub
+}
+
 //------------------------------------------------------------------------------
 
 // Conn is like Client, but its pool contains single connection.
@@ -3,6 +3,7 @@ package redis_test
 import (
 	"bytes"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/go-redis/redis"
@@ -369,3 +370,75 @@ var _ = Describe("Client OnConnect", func() {
 		Expect(name).To(Equal("on_connect"))
 	})
 })
+
+var _ = Describe("Client CredentialsProvider", func() {
+	var admin, client *redis.Client
+	var mu sync.Mutex
+	var username, password string
+	var calls int
+
+	BeforeEach(func() {
+		admin = redis.NewClient(redisOptions())
+		for _, user := range []string{"creds-1", "creds-2"} {
+			err := admin.ACLSetUser(user, "reset", "on", ">"+user, "~*", "+@all").Err()
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		username, password, calls = "creds-1", "creds-1", 0
+		opt := redisOptions()
+		opt.PoolSize = 1
+		opt.CredentialsProvider = func() (string, string, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			return username, password, nil
+		}
+		client = redis.NewClient(opt)
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+		err := admin.ACLDelUser("creds-1", "creds-2").Err()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(admin.Close()).NotTo(HaveOccurred())
+	})
+
+	It("authenticates new connections", func() {
+		name, err := client.ACLWhoAmI().Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).To(Equal("creds-1"))
+		Expect(calls).To(Equal(1))
+	})
+
+	It("re-authenticates pooled connections on ReAuth", func() {
+		Expect(client.Ping().Err()).NotTo(HaveOccurred())
+
+		mu.Lock()
+		username, password = "creds-2", "creds-2"
+		mu.Unlock()
+
+		name, err := client.ACLWhoAmI().Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).To(Equal("creds-1"))
+
+		client.ReAuth()
+
+		name, err = client.ACLWhoAmI().Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).To(Equal("creds-2"))
+		Expect(client.PoolStats().TotalConns).To(Equal(uint32(1)))
+	})
+
+	It("removes connections that fail to re-authenticate", func() {
+		Expect(client.Ping().Err()).NotTo(HaveOccurred())
+
+		mu.Lock()
+		password = "wrong"
+		mu.Unlock()
+		client.ReAuth()
+
+		err := client.Ping().Err()
+		Expect(err).To(HaveOccurred())
+		Expect(client.PoolStats().TotalConns).To(Equal(uint32(0)))
+	})
+})
@@ -123,6 +123,14 @@ func NewClusterSlotsCmdResult(val []ClusterSlot, err error) *ClusterSlotsCmd {
 	return &cmd
 }
 
+// NewClusterNodesCmdResult returns a ClusterNodesCmd initalised with val and err for testing
+func NewClusterNodesCmdResult(val []ClusterNodeInfo, err error) *ClusterNodesCmd {
+	var cmd ClusterNodesCmd
+	cmd.val = val
+	cmd.setErr(err)
+	return &cmd
+}
+
 // NewGeoLocationCmdResult returns a GeoLocationCmd initalised with val and err for testing
 func NewGeoLocationCmdResult(val []GeoLocation, err error) *GeoLocationCmd {
 	var cmd GeoLocationCmd
@@ -34,7 +34,10 @@ type RingOptions struct {
 
 	OnConnect func(*Conn) error
 
+	CredentialsProvider func() (username string, password string, err error)
+
 	DB       int
+	Username string
 	Password string
 
 	MaxRetries      int
@@ -49,6 +52,8 @@ type RingOptions struct {
 	PoolTimeout        time.Duration
 	IdleTimeout        time.Duration
 	IdleCheckFrequency time.Duration
+
+	PubSubHealthCheckFrequency time.Duration
 }
 
 func (opt *RingOptions) init() {
@@ -74,7 +79,10 @@ func (opt *RingOptions) clientOptions() *Options {
 	return &Options{
 		OnConnect: opt.OnConnect,
 
+		CredentialsProvider: opt.CredentialsProvider,
+
 		DB:       opt.DB,
+		Username: opt.Username,
 		Password: opt.Password,
 
 		DialTimeout:  opt.DialTimeout,
@@ -85,6 +93,8 @@ func (opt *RingOptions) clientOptions() *Options {
 		PoolTimeout:        opt.PoolTimeout,
 		IdleTimeout:        opt.IdleTimeout,
 		IdleCheckFrequency: opt.IdleCheckFrequency,
+
+		PubSubHealthCheckFrequency: opt.PubSubHealthCheckFrequency,
 	}
 }
 
@@ -560,6 +570,14 @@ func (c *Ring) TxPipelined(fn func(Pipeliner) error) ([]Cmder, error) {
 	panic("not implemented")
 }
 
+// ReAuth authenticates connections to every shard again,
+// see Client.ReAuth.
+func (c *Ring) ReAuth() {
+	for _, shard := range c.shards.List() {
+		shard.Client.ReAuth()
+	}
+}
+
 // Close closes the ring client, releasing any open resources.
 //
 // It is rare to Close a Ring, as the Ring is meant to be long-lived
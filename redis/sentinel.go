@@ -1,7 +1,11 @@
 package redis
 
 import (
+	"crypto/tls"
 	"errors"
+	"fmt"
+	"math"
+	"math/rand"
 	"net"
 	"strings"
 	"sync"
@@ -20,11 +24,44 @@ type FailoverOptions struct {
 	MasterName string
 	// A seed list of host:port addresses of sentinel nodes.
 	SentinelAddrs []string
+	// Optional username and password of sentinel nodes. Password must
+	// match the requirepass sentinel configuration option.
+	SentinelUsername string
+	SentinelPassword string
+	// SentinelCredentialsProvider returns username and password for
+	// sentinel connections and has priority over SentinelUsername and
+	// SentinelPassword options.
+	SentinelCredentialsProvider func() (username string, password string, err error)
+
+	// Hook that is called for every event published by Sentinel about
+	// the master, its replicas and sentinels and for events that are not
+	// related to any master, e.g. +tilt. Hook is called from a single
+	// goroutine and should not block.
+	OnSentinelEvent func(*SentinelEvent)
+
+	// Dialer creates new network connection to the sentinel, master or
+	// replica at the address. TLS is negotiated over the returned
+	// connection when TLSConfig is set.
+	// Default is net.DialTimeout with DialTimeout.
+	Dialer func(network, addr string) (net.Conn, error)
+
+	// Enables read-only commands on replicas. Commands are sent to
+	// a random replica that is not down or to the master if there is none.
+	ReadOnly bool
+	// Allows routing read-only commands to the closest replica.
+	// Implies ReadOnly.
+	RouteByLatency bool
+	// Allows routing read-only commands to the random master or replica.
+	// Implies ReadOnly.
+	RouteRandomly bool
 
 	// Following options are copied from Options struct.
 
 	OnConnect func(*Conn) error
 
+	CredentialsProvider func() (username string, password string, err error)
+
+	Username string
 	Password string
 	DB       int
 
@@ -38,6 +75,11 @@ type FailoverOptions struct {
 	PoolTimeout        time.Duration
 	IdleTimeout        time.Duration
 	IdleCheckFrequency time.Duration
+
+	PubSubHealthCheckFrequency time.Duration
+
+	// TLS Config used for sentinel, master and replica connections.
+	TLSConfig *tls.Config
 }
 
 func (opt *FailoverOptions) options() *Options {
@@ -46,7 +88,10 @@ func (opt *FailoverOptions) options() *Options {
 
 		OnConnect: opt.OnConnect,
 
+		CredentialsProvider: opt.CredentialsProvider,
+
 		DB:       opt.DB,
+		Username: opt.Username,
 		Password: opt.Password,
 
 		MaxRetries: opt.MaxRetries,
@@ -59,6 +104,10 @@ func (opt *FailoverOptions) options() *Options {
 		PoolTimeout:        opt.PoolTimeout,
 		IdleTimeout:        opt.IdleTimeout,
 		IdleCheckFrequency: opt.IdleCheckFrequency,
+
+		PubSubHealthCheckFrequency: opt.PubSubHealthCheckFrequency,
+
+		TLSConfig: opt.TLSConfig,
 	}
 }
 
@@ -70,10 +119,23 @@ func NewFailoverClient(failoverOpt *FailoverOptions) *Client { // 返回的也
 	opt.init()
 
 	failover := &sentinelFailover{
-		masterName:    failoverOpt.MasterName,
-		sentinelAddrs: failoverOpt.SentinelAddrs,
+		masterName:       failoverOpt.MasterName,
+		sentinelAddrs:    failoverOpt.SentinelAddrs,
+		sentinelUsername: failoverOpt.SentinelUsername,
+		sentinelPassword: failoverOpt.SentinelPassword,
+		sentinelCreds:    failoverOpt.SentinelCredentialsProvider,
+		dialer:           failoverOpt.Dialer,
+		onEvent:          failoverOpt.OnSentinelEvent,
 
 		opt: opt,
+
+		readOnly: failoverOpt.ReadOnly ||
+			failoverOpt.RouteByLatency ||
+			failoverOpt.RouteRandomly,
+		routeByLatency: failoverOpt.RouteByLatency,
+		routeRandomly:  failoverOpt.RouteRandomly,
+		replicaOpt:     failoverOpt.options(),
+		cmdsInfoCache:  newCmdsInfoCache(),
 	}
 
 	c := Client{
@@ -85,35 +147,113 @@ func NewFailoverClient(failoverOpt *FailoverOptions) *Client { // 返回的也
 			onClose: func() error {
 				return failover.Close()
 			},
+			onReAuth: failover.reAuth,
 		},
 	}
 	c.baseClient.init()
 	c.setProcessor(c.Process)
 
+	c.WrapProcess(failover.wrapProcess)
+
 	return &c
 }
 
 //------------------------------------------------------------------------------
 
-type sentinelClient struct {
-	cmdable
+// SentinelEvent is an event published by Sentinel,
+// see https://redis.io/topics/sentinel#pubsub-messages.
+type SentinelEvent struct {
+	// Event name, e.g. +sdown or +failover-state-select-slave.
+	Name string
+
+	// Instance the event is about: its type (master, slave or sentinel),
+	// name and address. For +switch-master it's the new master.
+	InstanceType string
+	InstanceName string
+	Host         string
+	Port         string
+
+	// Master the instance belongs to. Empty for events that are not
+	// related to any master, e.g. +tilt or +new-epoch.
+	MasterName string
+	MasterHost string
+	MasterPort string
+
+	// Payload is the message as published by Sentinel.
+	Payload string
+}
+
+func (e *SentinelEvent) String() string {
+	return fmt.Sprintf("SentinelEvent<%s: %s>", e.Name, e.Payload)
+}
+
+// ParseSentinelEvent parses a message published by Sentinel.
+// Instance details are left empty when the payload does not have them.
+func ParseSentinelEvent(msg *Message) *SentinelEvent {
+	e := &SentinelEvent{
+		Name:    msg.Channel,
+		Payload: msg.Payload,
+	}
+	parts := strings.Split(msg.Payload, " ")
+
+	if msg.Channel == "+switch-master" {
+		// <master-name> <old-ip> <old-port> <new-ip> <new-port>
+		if len(parts) == 5 {
+			e.InstanceType = "master"
+			e.InstanceName = parts[0]
+			e.Host, e.Port = parts[3], parts[4]
+			e.MasterName = parts[0]
+			e.MasterHost, e.MasterPort = parts[3], parts[4]
+		}
+		return e
+	}
+
+	// <instance-type> <name> <ip> <port> @ <master-name> <master-ip> <master-port>
+	// The @ part is omitted for masters.
+	if len(parts) < 4 {
+		return e
+	}
+	switch parts[0] {
+	case "master", "slave", "sentinel":
+	default:
+		return e
+	}
+
+	e.InstanceType = parts[0]
+	e.InstanceName = parts[1]
+	e.Host, e.Port = parts[2], parts[3]
+	if len(parts) >= 8 && parts[4] == "@" {
+		e.MasterName = parts[5]
+		e.MasterHost, e.MasterPort = parts[6], parts[7]
+	} else if e.InstanceType == "master" {
+		e.MasterName = e.InstanceName
+		e.MasterHost, e.MasterPort = e.Host, e.Port
+	}
+	return e
+}
+
+//------------------------------------------------------------------------------
+
+// SentinelClient is a client for Redis Sentinel. It's safe for concurrent
+// use by multiple goroutines.
+type SentinelClient struct {
 	baseClient
 }
 
-func newSentinel(opt *Options) *sentinelClient {
+// NewSentinelClient returns a client to the Sentinel specified by Options.
+func NewSentinelClient(opt *Options) *SentinelClient {
 	opt.init()
-	c := sentinelClient{
+	c := SentinelClient{
 		baseClient: baseClient{
 			opt:      opt,
 			connPool: newConnPool(opt),
 		},
 	}
 	c.baseClient.init()
-	c.cmdable.setProcessor(c.Process)
 	return &c
 }
 
-func (c *sentinelClient) PubSub() *PubSub {
+func (c *SentinelClient) PubSub() *PubSub {
 	return &PubSub{
 		opt: c.opt,
 
@@ -124,22 +264,131 @@ func (c *sentinelClient) PubSub() *PubSub {
 	}
 }
 
-func (c *sentinelClient) GetMasterAddrByName(name string) *StringSliceCmd {
-	cmd := NewStringSliceCmd("SENTINEL", "get-master-addr-by-name", name)
+func (c *SentinelClient) Ping() *StatusCmd {
+	cmd := NewStatusCmd("ping")
+	c.Process(cmd)
+	return cmd
+}
+
+// GetMasterAddrByName returns host and port of the master.
+func (c *SentinelClient) GetMasterAddrByName(name string) *StringSliceCmd {
+	cmd := NewStringSliceCmd("sentinel", "get-master-addr-by-name", name)
+	c.Process(cmd)
+	return cmd
+}
+
+// Sentinels returns state of the other Sentinels monitoring the master.
+func (c *SentinelClient) Sentinels(name string) *StringStringMapSliceCmd {
+	cmd := NewStringStringMapSliceCmd("sentinel", "sentinels", name)
+	c.Process(cmd)
+	return cmd
+}
+
+// Masters returns state of all monitored masters.
+func (c *SentinelClient) Masters() *StringStringMapSliceCmd {
+	cmd := NewStringStringMapSliceCmd("sentinel", "masters")
+	c.Process(cmd)
+	return cmd
+}
+
+// Master returns state of the master.
+func (c *SentinelClient) Master(name string) *StringStringMapCmd {
+	cmd := NewStringStringMapCmd("sentinel", "master", name)
+	c.Process(cmd)
+	return cmd
+}
+
+// Slaves returns state of the master replicas.
+func (c *SentinelClient) Slaves(name string) *StringStringMapSliceCmd {
+	cmd := NewStringStringMapSliceCmd("sentinel", "slaves", name)
+	c.Process(cmd)
+	return cmd
+}
+
+// Replicas is like Slaves, but uses the command name introduced
+// in Redis 5.
+func (c *SentinelClient) Replicas(name string) *StringStringMapSliceCmd {
+	cmd := NewStringStringMapSliceCmd("sentinel", "replicas", name)
+	c.Process(cmd)
+	return cmd
+}
+
+// CkQuorum checks if the current Sentinel configuration is able to reach
+// the quorum needed to failover the master and the majority needed
+// to authorize the failover.
+func (c *SentinelClient) CkQuorum(name string) *StringCmd {
+	cmd := NewStringCmd("sentinel", "ckquorum", name)
+	c.Process(cmd)
+	return cmd
+}
+
+// Failover forces a failover as if the master was not reachable
+// and without asking for agreement to other Sentinels.
+func (c *SentinelClient) Failover(name string) *StatusCmd {
+	cmd := NewStatusCmd("sentinel", "failover", name)
+	c.Process(cmd)
+	return cmd
+}
+
+// Reset resets all masters with matching name and returns the number
+// of reset masters.
+func (c *SentinelClient) Reset(pattern string) *IntCmd {
+	cmd := NewIntCmd("sentinel", "reset", pattern)
+	c.Process(cmd)
+	return cmd
+}
+
+// Monitor tells Sentinel to start monitoring a new master.
+func (c *SentinelClient) Monitor(name, host, port string, quorum int) *StatusCmd {
+	cmd := NewStatusCmd("sentinel", "monitor", name, host, port, quorum)
 	c.Process(cmd)
 	return cmd
 }
 
-func (c *sentinelClient) Sentinels(name string) *SliceCmd {
-	cmd := NewSliceCmd("SENTINEL", "sentinels", name)
+// Remove tells Sentinel to stop monitoring the master.
+func (c *SentinelClient) Remove(name string) *StatusCmd {
+	cmd := NewStatusCmd("sentinel", "remove", name)
+	c.Process(cmd)
+	return cmd
+}
+
+// Set changes configuration parameter of the monitored master.
+func (c *SentinelClient) Set(name, option, value string) *StatusCmd {
+	cmd := NewStatusCmd("sentinel", "set", name, option, value)
+	c.Process(cmd)
+	return cmd
+}
+
+// FlushConfig forces Sentinel to rewrite its configuration on disk.
+func (c *SentinelClient) FlushConfig() *StatusCmd {
+	cmd := NewStatusCmd("sentinel", "flushconfig")
+	c.Process(cmd)
+	return cmd
+}
+
+// InfoCache returns INFO replies of the masters and their replicas
+// cached by Sentinel. All masters are returned if names are omitted.
+func (c *SentinelClient) InfoCache(names ...string) *SentinelInfoCacheCmd {
+	args := make([]interface{}, 2+len(names))
+	args[0] = "sentinel"
+	args[1] = "info-cache"
+	for i, name := range names {
+		args[2+i] = name
+	}
+	cmd := NewSentinelInfoCacheCmd(args...)
 	c.Process(cmd)
 	return cmd
 }
 
 type sentinelFailover struct {
-	sentinelAddrs []string
+	sentinelAddrs    []string
+	sentinelUsername string
+	sentinelPassword string
+	sentinelCreds    func() (username string, password string, err error)
 
-	opt *Options
+	opt     *Options
+	dialer  func(network, addr string) (net.Conn, error)
+	onEvent func(*SentinelEvent)
 
 	pool     *pool.ConnPool
 	poolOnce sync.Once
@@ -147,13 +396,41 @@ type sentinelFailover struct {
 	mu          sync.RWMutex
 	masterName  string
 	_masterAddr string
-	sentinel    *sentinelClient
+	sentinel    *SentinelClient
+
+	readOnly       bool
+	routeByLatency bool
+	routeRandomly  bool
+	replicaOpt     *Options
+	cmdsInfoCache  *cmdsInfoCache
+
+	replicasMu     sync.RWMutex
+	replicas       []*clusterNode
+	replicasClosed bool
 }
 
 func (d *sentinelFailover) Close() error {
+	d.closeReplicas()
 	return d.resetSentinel()
 }
 
+// reAuth authenticates connections to replicas and
+// the current sentinel again.
+func (d *sentinelFailover) reAuth() {
+	d.mu.RLock()
+	sentinel := d.sentinel
+	d.mu.RUnlock()
+	if sentinel != nil {
+		sentinel.ReAuth()
+	}
+
+	d.replicasMu.RLock()
+	for _, node := range d.replicas {
+		node.Client.ReAuth()
+	}
+	d.replicasMu.RUnlock()
+}
+
 func (d *sentinelFailover) Pool() *pool.ConnPool {
 	d.poolOnce.Do(func() {
 		d.opt.Dialer = d.dial
@@ -168,7 +445,23 @@ func (d *sentinelFailover) dial() (net.Conn, error) {
 	if err != nil {
 		return nil, err
 	}
-	return net.DialTimeout("tcp", addr, d.opt.DialTimeout)
+	return d.dialAddr(addr)
+}
+
+// dialAddr dials the sentinel, master or replica at the address.
+func (d *sentinelFailover) dialAddr(addr string) (net.Conn, error) {
+	var conn net.Conn
+	var err error
+	if d.dialer != nil {
+		conn, err = d.dialer("tcp", addr)
+	} else {
+		conn, err = net.DialTimeout("tcp", addr, d.opt.DialTimeout)
+	}
+	if d.opt.TLSConfig == nil || err != nil {
+		return conn, err
+	}
+	t := tls.Client(conn, d.opt.TLSConfig)
+	return t, t.Handshake()
 }
 
 func (d *sentinelFailover) MasterAddr() (string, error) {
@@ -205,8 +498,13 @@ func (d *sentinelFailover) masterAddr() (string, error) {
 	}
 
 	for i, sentinelAddr := range d.sentinelAddrs {
-		sentinel := newSentinel(&Options{
-			Addr: sentinelAddr,
+		sentinel := NewSentinelClient(&Options{
+			Addr:     sentinelAddr,
+			Dialer:   d.addrDialer(sentinelAddr),
+			Username: d.sentinelUsername,
+			Password: d.sentinelPassword,
+
+			CredentialsProvider: d.sentinelCreds,
 
 			DialTimeout:  d.opt.DialTimeout,
 			ReadTimeout:  d.opt.ReadTimeout,
@@ -240,14 +538,17 @@ func (d *sentinelFailover) switchMaster(masterAddr string) {
 		"sentinel: new master=%q addr=%q",
 		d.masterName, masterAddr,
 	)
-	_ = d.Pool().Filter(func(cn *pool.Conn) bool {
+	_ = d.Pool().Drain(func(cn *pool.Conn) bool {
 		return cn.RemoteAddr().String() != masterAddr
 	})
 	d._masterAddr = masterAddr
 }
 
-func (d *sentinelFailover) setSentinel(sentinel *sentinelClient) {
+func (d *sentinelFailover) setSentinel(sentinel *SentinelClient) {
 	d.discoverSentinels(sentinel)
+	if d.readOnly {
+		d.discoverReplicas(sentinel)
+	}
 	d.sentinel = sentinel
 	go d.listen(sentinel)
 }
@@ -268,37 +569,41 @@ func (d *sentinelFailover) _resetSentinel() error {
 	return err
 }
 
-func (d *sentinelFailover) discoverSentinels(sentinel *sentinelClient) {
+func (d *sentinelFailover) discoverSentinels(sentinel *SentinelClient) {
 	sentinels, err := sentinel.Sentinels(d.masterName).Result()
 	if err != nil {
 		internal.Logf("sentinel: Sentinels master=%q failed: %s", d.masterName, err)
 		return
 	}
 	for _, sentinel := range sentinels {
-		vals := sentinel.([]interface{})
-		for i := 0; i < len(vals); i += 2 {
-			key := vals[i].(string)
-			if key == "name" {
-				sentinelAddr := vals[i+1].(string)
-				if !contains(d.sentinelAddrs, sentinelAddr) {
-					internal.Logf(
-						"sentinel: discovered new sentinel=%q for master=%q",
-						sentinelAddr, d.masterName,
-					)
-					d.sentinelAddrs = append(d.sentinelAddrs, sentinelAddr)
-				}
-			}
+		sentinelAddr, ok := sentinel["name"]
+		if ok && !contains(d.sentinelAddrs, sentinelAddr) {
+			internal.Logf(
+				"sentinel: discovered new sentinel=%q for master=%q",
+				sentinelAddr, d.masterName,
+			)
+			d.sentinelAddrs = append(d.sentinelAddrs, sentinelAddr)
 		}
 	}
 }
 
-func (d *sentinelFailover) listen(sentinel *sentinelClient) {
+func (d *sentinelFailover) listen(sentinel *SentinelClient) {
 	var pubsub *PubSub
 	for {
 		if pubsub == nil {
 			pubsub = sentinel.PubSub()
 
-			if err := pubsub.Subscribe("+switch-master"); err != nil {
+			var err error
+			if d.onEvent != nil {
+				err = pubsub.PSubscribe("*")
+			} else {
+				channels := []string{"+switch-master"}
+				if d.readOnly {
+					channels = append(channels, "+slave", "+sdown", "-sdown")
+				}
+				err = pubsub.Subscribe(channels...)
+			}
+			if err != nil {
 				internal.Logf("sentinel: Subscribe failed: %s", err)
 				pubsub.Close()
 				d.resetSentinel()
@@ -321,7 +626,7 @@ func (d *sentinelFailover) listen(sentinel *sentinelClient) {
 			parts := strings.Split(msg.Payload, " ")
 			if parts[0] != d.masterName {
 				internal.Logf("sentinel: ignore addr for master=%q", parts[0])
-				continue
+				break
 			}
 			addr := net.JoinHostPort(parts[3], parts[4])
 
@@ -330,8 +635,219 @@ func (d *sentinelFailover) listen(sentinel *sentinelClient) {
 				d.switchMaster(addr)
 			}
 			d.mu.Unlock()
+
+			if d.readOnly {
+				d.discoverReplicas(sentinel)
+			}
+		case "+slave", "+sdown", "-sdown":
+			if d.readOnly && isReplicaEvent(msg.Payload, d.masterName) {
+				d.discoverReplicas(sentinel)
+			}
+		}
+
+		if d.onEvent != nil {
+			e := ParseSentinelEvent(msg)
+			if e.MasterName == "" || e.MasterName == d.masterName {
+				d.onEvent(e)
+			}
+		}
+	}
+}
+
+func (d *sentinelFailover) addrDialer(addr string) func() (net.Conn, error) {
+	return func() (net.Conn, error) {
+		return d.dialAddr(addr)
+	}
+}
+
+// isReplicaEvent reports whether the event payload is about a replica
+// of the master. Replica payloads have the following format:
+// slave <name> <ip> <port> @ <master-name> <master-ip> <master-port>.
+func isReplicaEvent(payload, masterName string) bool {
+	parts := strings.Split(payload, " ")
+	return len(parts) >= 6 &&
+		parts[0] == "slave" &&
+		parts[4] == "@" &&
+		parts[5] == masterName
+}
+
+func (d *sentinelFailover) discoverReplicas(sentinel *SentinelClient) {
+	replicas, err := sentinel.Slaves(d.masterName).Result()
+	if err != nil {
+		internal.Logf("sentinel: Slaves master=%q failed: %s", d.masterName, err)
+		return
+	}
+	d.setReplicas(replicaAddrs(replicas))
+}
+
+// replicaAddrs returns addresses of replicas that are not down
+// or disconnected.
+func replicaAddrs(replicas []map[string]string) []string {
+	var addrs []string
+	for _, replica := range replicas {
+		if hasFlag(replica["flags"], "s_down") ||
+			hasFlag(replica["flags"], "o_down") ||
+			hasFlag(replica["flags"], "disconnected") {
+			continue
+		}
+		addrs = append(addrs, net.JoinHostPort(replica["ip"], replica["port"]))
+	}
+	return addrs
+}
+
+func hasFlag(flags, flag string) bool {
+	for _, f := range strings.Split(flags, ",") {
+		if f == flag {
+			return true
 		}
 	}
+	return false
+}
+
+// setReplicas replaces the replica set keeping clients of replicas
+// that are still in the set. It does nothing once replicas are closed,
+// because listen may discover replicas after Close.
+func (d *sentinelFailover) setReplicas(addrs []string) {
+	d.replicasMu.Lock()
+	defer d.replicasMu.Unlock()
+
+	if d.replicasClosed {
+		return
+	}
+
+	old := make(map[string]*clusterNode, len(d.replicas))
+	for _, node := range d.replicas {
+		old[node.Client.getAddr()] = node
+	}
+
+	replicas := make([]*clusterNode, 0, len(addrs))
+	for _, addr := range addrs {
+		node, ok := old[addr]
+		if ok {
+			delete(old, addr)
+		} else {
+			internal.Logf("sentinel: new replica=%q for master=%q", addr, d.masterName)
+			node = d.newReplica(addr)
+		}
+		replicas = append(replicas, node)
+	}
+	d.replicas = replicas
+
+	for _, node := range old {
+		_ = node.Close()
+	}
+}
+
+func (d *sentinelFailover) closeReplicas() {
+	d.replicasMu.Lock()
+	defer d.replicasMu.Unlock()
+
+	d.replicasClosed = true
+	for _, node := range d.replicas {
+		_ = node.Close()
+	}
+	d.replicas = nil
+}
+
+func (d *sentinelFailover) newReplica(addr string) *clusterNode {
+	opt := *d.replicaOpt
+	opt.Addr = addr
+	opt.Dialer = d.addrDialer(addr)
+	node := &clusterNode{
+		Client:  NewClient(&opt),
+		latency: math.MaxUint32,
+	}
+	if d.routeByLatency {
+		go node.updateLatency()
+	}
+	return node
+}
+
+// replica returns the client that serves read-only commands
+// or nil if the command should be sent to the master.
+func (d *sentinelFailover) replica() *Client {
+	d.replicasMu.RLock()
+	defer d.replicasMu.RUnlock()
+
+	if len(d.replicas) == 0 {
+		return nil
+	}
+
+	if d.routeByLatency {
+		var closest *clusterNode
+		for _, node := range d.replicas {
+			if closest == nil || node.Latency() < closest.Latency() {
+				closest = node
+			}
+		}
+		return closest.Client
+	}
+
+	if d.routeRandomly {
+		n := rand.Intn(len(d.replicas) + 1)
+		if n == len(d.replicas) {
+			return nil
+		}
+		return d.replicas[n].Client
+	}
+
+	return d.replicas[rand.Intn(len(d.replicas))].Client
+}
+
+// wrapProcess sends read-only commands to replicas and switches to
+// the new master when the old one is demoted. Read-only commands that
+// fail on a replica with a network error are retried on the master.
+// Pipelines and transactions are always sent to the master.
+func (d *sentinelFailover) wrapProcess(process func(Cmder) error) func(Cmder) error {
+	return func(cmd Cmder) error {
+		if d.readOnly && d.cmdReadOnly(cmd, process) {
+			if replica := d.replica(); replica != nil {
+				err := replica.Process(cmd)
+				if err == nil || !internal.IsNetworkError(err) {
+					return err
+				}
+				internal.Logf("sentinel: replica=%q failed: %s", replica.getAddr(), err)
+			}
+		}
+
+		err := process(cmd)
+		if err != nil && internal.IsReadOnlyError(err) && d.resolveMaster() {
+			// Commands rejected with READONLY are not executed,
+			// so they are safe to retry on the new master.
+			err = process(cmd)
+		}
+		return err
+	}
+}
+
+// resolveMaster asks sentinel for the master address and switches
+// to it. Connections to other nodes, e.g. the connection that replied
+// with READONLY, are closed even if the master address was already
+// updated by +switch-master, so the retried command is sent to
+// the master. It reports whether the master is resolved.
+func (d *sentinelFailover) resolveMaster() bool {
+	addr, err := d.MasterAddr()
+	if err != nil {
+		internal.Logf("sentinel: resolving master=%q failed: %s", d.masterName, err)
+		return false
+	}
+	_ = d.Pool().Drain(func(cn *pool.Conn) bool {
+		return cn.RemoteAddr().String() != addr
+	})
+	return true
+}
+
+func (d *sentinelFailover) cmdReadOnly(cmd Cmder, process func(Cmder) error) bool {
+	cmdsInfo, err := d.cmdsInfoCache.Do(func() (map[string]*CommandInfo, error) {
+		cmd := NewCommandsInfoCmd("command")
+		_ = process(cmd)
+		return cmd.Result()
+	})
+	if err != nil {
+		return false
+	}
+	info := cmdsInfo[cmd.Name()]
+	return info != nil && info.ReadOnly
 }
 
 func contains(slice []string, str string) bool {
@@ -1,6 +1,12 @@
 package redis_test
 
 import (
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
 	"github.com/go-redis/redis"
 
 	. "github.com/onsi/ginkgo"
@@ -9,11 +15,22 @@ import (
 
 var _ = Describe("Sentinel", func() {
 	var client *redis.Client
+	var eventsMu sync.Mutex
+	var events []string
 
 	BeforeEach(func() {
+		eventsMu.Lock()
+		events = nil
+		eventsMu.Unlock()
+
 		client = redis.NewFailoverClient(&redis.FailoverOptions{
 			MasterName:    sentinelName,
 			SentinelAddrs: []string{":" + sentinelPort},
+			OnSentinelEvent: func(e *redis.SentinelEvent) {
+				eventsMu.Lock()
+				events = append(events, e.Name)
+				eventsMu.Unlock()
+			},
 		})
 		Expect(client.FlushDB().Err()).NotTo(HaveOccurred())
 	})
@@ -64,6 +81,16 @@ var _ = Describe("Sentinel", func() {
 			return client.Get("foo").Err()
 		}, "5s", "100ms").ShouldNot(HaveOccurred())
 
+		Eventually(func() []string {
+			eventsMu.Lock()
+			defer eventsMu.Unlock()
+			return append([]string(nil), events...)
+		}, "5s", "100ms").Should(ContainElement("+switch-master"))
+		eventsMu.Lock()
+		Expect(events).To(ContainElement("+odown"))
+		Expect(events).To(ContainElement("+failover-state-select-slave"))
+		eventsMu.Unlock()
+
 		// Publish message to check if subscription is renewed.
 		err = client.Publish("foo", "hello").Err()
 		Expect(err).NotTo(HaveOccurred())
@@ -85,4 +112,320 @@ var _ = Describe("Sentinel", func() {
 		err := client.Ping().Err()
 		Expect(err).NotTo(HaveOccurred())
 	})
+
+	It("dials sentinels and master with custom dialer", func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+
+		var mu sync.Mutex
+		var dialed []string
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    sentinelName,
+			SentinelAddrs: []string{":" + sentinelPort},
+			Dialer: func(network, addr string) (net.Conn, error) {
+				mu.Lock()
+				dialed = append(dialed, addr)
+				mu.Unlock()
+				return net.Dial(network, addr)
+			},
+		})
+		Expect(client.Ping().Err()).NotTo(HaveOccurred())
+
+		mu.Lock()
+		defer mu.Unlock()
+		Expect(dialed).To(ContainElement(":" + sentinelPort))
+		Expect(len(dialed)).To(BeNumerically(">=", 2))
+	})
+
+	It("retries on master after READONLY", func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+
+		sentinelClient := redis.NewSentinelClient(&redis.Options{
+			Addr: ":" + sentinelPort,
+		})
+		defer sentinelClient.Close()
+
+		master, err := sentinelClient.GetMasterAddrByName(sentinelName).Result()
+		Expect(err).NotTo(HaveOccurred())
+		masterAddr := net.JoinHostPort(master[0], master[1])
+
+		slaves, err := sentinelClient.Slaves(sentinelName).Result()
+		Expect(err).NotTo(HaveOccurred())
+		replicaAddrs := redis.SentinelReplicaAddrs(slaves)
+		Expect(replicaAddrs).NotTo(BeEmpty())
+
+		// First master connection is made to a replica as if
+		// the master was demoted.
+		var redirected uint32
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    sentinelName,
+			SentinelAddrs: []string{":" + sentinelPort},
+			Dialer: func(network, addr string) (net.Conn, error) {
+				if addr == masterAddr && atomic.CompareAndSwapUint32(&redirected, 0, 1) {
+					addr = replicaAddrs[0]
+				}
+				return net.Dial(network, addr)
+			},
+		})
+
+		err = client.Set("foo", "bar", 0).Err()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(atomic.LoadUint32(&redirected)).To(Equal(uint32(1)))
+	})
+
+	It("authenticates to sentinels", func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       sentinelName,
+			SentinelAddrs:    []string{":" + sentinelPort},
+			SentinelPassword: "password",
+		})
+		err := client.Ping().Err()
+		Expect(err).To(MatchError("redis: all sentinels are unreachable"))
+	})
+})
+
+var _ = Describe("ParseSentinelEvent", func() {
+	It("parses slave event", func() {
+		e := redis.ParseSentinelEvent(&redis.Message{
+			Channel: "+sdown",
+			Payload: "slave 127.0.0.1:8124 127.0.0.1 8124 @ mymaster 127.0.0.1 8123",
+		})
+		Expect(e.Name).To(Equal("+sdown"))
+		Expect(e.InstanceType).To(Equal("slave"))
+		Expect(e.InstanceName).To(Equal("127.0.0.1:8124"))
+		Expect(e.Host).To(Equal("127.0.0.1"))
+		Expect(e.Port).To(Equal("8124"))
+		Expect(e.MasterName).To(Equal("mymaster"))
+		Expect(e.MasterHost).To(Equal("127.0.0.1"))
+		Expect(e.MasterPort).To(Equal("8123"))
+	})
+
+	It("parses master event with extra fields", func() {
+		e := redis.ParseSentinelEvent(&redis.Message{
+			Channel: "+odown",
+			Payload: "master mymaster 127.0.0.1 8123 #quorum 1/1",
+		})
+		Expect(e.InstanceType).To(Equal("master"))
+		Expect(e.MasterName).To(Equal("mymaster"))
+		Expect(e.MasterPort).To(Equal("8123"))
+		Expect(e.Payload).To(Equal("master mymaster 127.0.0.1 8123 #quorum 1/1"))
+	})
+
+	It("parses +switch-master", func() {
+		e := redis.ParseSentinelEvent(&redis.Message{
+			Channel: "+switch-master",
+			Payload: "mymaster 127.0.0.1 8123 127.0.0.1 8124",
+		})
+		Expect(e.MasterName).To(Equal("mymaster"))
+		Expect(e.Host).To(Equal("127.0.0.1"))
+		Expect(e.Port).To(Equal("8124"))
+	})
+
+	It("parses events without instance", func() {
+		e := redis.ParseSentinelEvent(&redis.Message{
+			Channel: "+tilt",
+			Payload: "#tilt",
+		})
+		Expect(e.Name).To(Equal("+tilt"))
+		Expect(e.InstanceType).To(Equal(""))
+		Expect(e.MasterName).To(Equal(""))
+	})
+})
+
+var _ = Describe("SentinelClient", func() {
+	var client *redis.SentinelClient
+
+	BeforeEach(func() {
+		client = redis.NewSentinelClient(&redis.Options{
+			Addr: ":" + sentinelPort,
+		})
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	It("should Master and Masters", func() {
+		master, err := client.Master(sentinelName).Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(master["name"]).To(Equal(sentinelName))
+		Expect(master["flags"]).To(ContainSubstring("master"))
+
+		masters, err := client.Masters().Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(masters).To(ContainElement(HaveKeyWithValue("name", sentinelName)))
+	})
+
+	It("should GetMasterAddrByName", func() {
+		addr, err := client.GetMasterAddrByName(sentinelName).Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(addr).To(HaveLen(2))
+	})
+
+	It("should Slaves and Sentinels", func() {
+		slaves, err := client.Slaves(sentinelName).Result()
+		Expect(err).NotTo(HaveOccurred())
+		for _, slave := range slaves {
+			Expect(slave["flags"]).To(ContainSubstring("slave"))
+		}
+
+		sentinels, err := client.Sentinels(sentinelName).Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sentinels).To(BeEmpty())
+	})
+
+	It("should CkQuorum", func() {
+		val, err := client.CkQuorum(sentinelName).Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(val).To(HavePrefix("OK"))
+	})
+
+	It("should InfoCache", func() {
+		infos, err := client.InfoCache(sentinelName).Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(infos).To(HaveKey(sentinelName))
+		Expect(infos[sentinelName]).NotTo(BeEmpty())
+	})
+
+	It("should Monitor, Set and Remove", func() {
+		err := client.Monitor("othermaster", "127.0.0.1", sentinelSlave1Port, 1).Err()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = client.Set("othermaster", "quorum", "2").Err()
+		Expect(err).NotTo(HaveOccurred())
+
+		master, err := client.Master("othermaster").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(master["quorum"]).To(Equal("2"))
+
+		n, err := client.Reset("othermaster").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(int64(1)))
+
+		err = client.Remove("othermaster").Err()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = client.Master("othermaster").Err()
+		Expect(err).To(MatchError("ERR No such master with that name"))
+	})
+})
+
+var _ = Describe("Sentinel replica reads", func() {
+	var client *redis.Client
+
+	BeforeEach(func() {
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    sentinelName,
+			SentinelAddrs: []string{":" + sentinelPort},
+			ReadOnly:      true,
+		})
+		// Replicas are discovered when the master is resolved.
+		Expect(client.Ping().Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+	})
+
+	getCalls := func(replicas []*redis.Client) int {
+		var calls int
+		for _, replica := range replicas {
+			info := replica.Info("commandstats").Val()
+			i := strings.Index(info, "cmdstat_get:calls=")
+			if i == -1 {
+				continue
+			}
+			s := info[i+len("cmdstat_get:calls="):]
+			n, err := strconv.Atoi(s[:strings.Index(s, ",")])
+			Expect(err).NotTo(HaveOccurred())
+			calls += n
+		}
+		return calls
+	}
+
+	It("sends read-only commands to replicas", func() {
+		sentinelClient := redis.NewSentinelClient(&redis.Options{
+			Addr: ":" + sentinelPort,
+		})
+		defer sentinelClient.Close()
+
+		slaves, err := sentinelClient.Slaves(sentinelName).Result()
+		Expect(err).NotTo(HaveOccurred())
+
+		var replicas []*redis.Client
+		for _, addr := range redis.SentinelReplicaAddrs(slaves) {
+			replica := redis.NewClient(&redis.Options{Addr: addr})
+			defer replica.Close()
+			Expect(replica.ConfigResetStat().Err()).NotTo(HaveOccurred())
+			replicas = append(replicas, replica)
+		}
+		Expect(replicas).NotTo(BeEmpty())
+
+		Expect(client.Set("foo", "bar", 0).Err()).NotTo(HaveOccurred())
+		for i := 0; i < 10; i++ {
+			err := client.Get("foo").Err()
+			Expect(err == nil || err == redis.Nil).To(BeTrue())
+		}
+		Expect(getCalls(replicas)).To(Equal(10))
+	})
+
+	It("retries read-only commands on master when replica is unreachable", func() {
+		Expect(client.Close()).NotTo(HaveOccurred())
+
+		sentinelClient := redis.NewSentinelClient(&redis.Options{
+			Addr: ":" + sentinelPort,
+		})
+		defer sentinelClient.Close()
+
+		slaves, err := sentinelClient.Slaves(sentinelName).Result()
+		Expect(err).NotTo(HaveOccurred())
+		replicaAddrs := redis.SentinelReplicaAddrs(slaves)
+		Expect(replicaAddrs).NotTo(BeEmpty())
+
+		var replicaDials uint32
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    sentinelName,
+			SentinelAddrs: []string{":" + sentinelPort},
+			ReadOnly:      true,
+			Dialer: func(network, addr string) (net.Conn, error) {
+				for _, replicaAddr := range replicaAddrs {
+					if addr == replicaAddr {
+						atomic.AddUint32(&replicaDials, 1)
+						// Nothing listens on the port.
+						addr = "127.0.0.1:1"
+					}
+				}
+				return net.Dial(network, addr)
+			},
+		})
+
+		Expect(client.Set("foo", "bar", 0).Err()).NotTo(HaveOccurred())
+		val, err := client.Get("foo").Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(val).To(Equal("bar"))
+		Expect(atomic.LoadUint32(&replicaDials)).To(BeNumerically(">=", 1))
+	})
+
+	It("skips replicas that are down", func() {
+		addrs := redis.SentinelReplicaAddrs([]map[string]string{
+			{"ip": "127.0.0.1", "port": "1", "flags": "slave"},
+			{"ip": "127.0.0.1", "port": "2", "flags": "s_down,slave"},
+			{"ip": "127.0.0.1", "port": "3", "flags": "slave,o_down"},
+			{"ip": "127.0.0.1", "port": "4", "flags": "slave,disconnected"},
+		})
+		Expect(addrs).To(Equal([]string{"127.0.0.1:1"}))
+	})
+
+	It("does not add replicas after Close", func() {
+		n := redis.SentinelReplicasAfterClose([]string{"127.0.0.1:8124"})
+		Expect(n).To(Equal(0))
+	})
+
+	It("recognizes replica events", func() {
+		payload := "slave 127.0.0.1:8124 127.0.0.1 8124 @ mymaster 127.0.0.1 8123"
+		Expect(redis.IsSentinelReplicaEvent(payload, "mymaster")).To(BeTrue())
+		Expect(redis.IsSentinelReplicaEvent(payload, "othermaster")).To(BeFalse())
+		Expect(redis.IsSentinelReplicaEvent("master mymaster 127.0.0.1 8123", "mymaster")).To(BeFalse())
+	})
 })
@@ -12,6 +12,10 @@ type UniversalOptions struct {
 	// The sentinel master name.
 	// Only failover clients.
 	MasterName string
+	// Optional username and password of sentinel nodes.
+	// Only failover clients.
+	SentinelUsername string
+	SentinelPassword string
 
 	// Database to be selected after connecting to the server.
 	// Only single-node and failover clients.
@@ -19,15 +23,18 @@ type UniversalOptions struct {
 
 	// Only cluster clients.
 
-	// Enables read only queries on slave nodes.
-	ReadOnly bool
+	MaxRedirects int
+
+	// Only cluster and failover clients.
 
-	MaxRedirects   int
+	// Enables read only queries on slave nodes.
+	ReadOnly       bool
 	RouteByLatency bool
 
 	// Common options
 
 	MaxRetries         int
+	Username           string
 	Password           string
 	DialTimeout        time.Duration
 	ReadTimeout        time.Duration
@@ -36,6 +43,13 @@ type UniversalOptions struct {
 	PoolTimeout        time.Duration
 	IdleTimeout        time.Duration
 	IdleCheckFrequency time.Duration
+
+	// CredentialsProvider has priority over Username and Password.
+	// ReAuth is not part of UniversalClient, so call it on the concrete
+	// *Client or *ClusterClient when credentials are rotated.
+	CredentialsProvider func() (username string, password string, err error)
+
+	PubSubHealthCheckFrequency time.Duration
 }
 
 func (o *UniversalOptions) cluster() *ClusterOptions {
@@ -50,6 +64,7 @@ func (o *UniversalOptions) cluster() *ClusterOptions {
 		ReadOnly:       o.ReadOnly,
 
 		MaxRetries:         o.MaxRetries,
+		Username:           o.Username,
 		Password:           o.Password,
 		DialTimeout:        o.DialTimeout,
 		ReadTimeout:        o.ReadTimeout,
@@ -58,6 +73,9 @@ func (o *UniversalOptions) cluster() *ClusterOptions {
 		PoolTimeout:        o.PoolTimeout,
 		IdleTimeout:        o.IdleTimeout,
 		IdleCheckFrequency: o.IdleCheckFrequency,
+
+		CredentialsProvider:        o.CredentialsProvider,
+		PubSubHealthCheckFrequency: o.PubSubHealthCheckFrequency,
 	}
 }
 
@@ -67,11 +85,17 @@ func (o *UniversalOptions) failover() *FailoverOptions {
 	}
 
 	return &FailoverOptions{
-		SentinelAddrs: o.Addrs,
-		MasterName:    o.MasterName,
-		DB:            o.DB,
+		SentinelAddrs:    o.Addrs,
+		MasterName:       o.MasterName,
+		SentinelUsername: o.SentinelUsername,
+		SentinelPassword: o.SentinelPassword,
+		DB:               o.DB,
+
+		ReadOnly:       o.ReadOnly,
+		RouteByLatency: o.RouteByLatency,
 
 		MaxRetries:         o.MaxRetries,
+		Username:           o.Username,
 		Password:           o.Password,
 		DialTimeout:        o.DialTimeout,
 		ReadTimeout:        o.ReadTimeout,
@@ -80,6 +104,9 @@ func (o *UniversalOptions) failover() *FailoverOptions {
 		PoolTimeout:        o.PoolTimeout,
 		IdleTimeout:        o.IdleTimeout,
 		IdleCheckFrequency: o.IdleCheckFrequency,
+
+		CredentialsProvider:        o.CredentialsProvider,
+		PubSubHealthCheckFrequency: o.PubSubHealthCheckFrequency,
 	}
 }
 
@@ -94,6 +121,7 @@ func (o *UniversalOptions) simple() *Options {
 		DB:   o.DB,
 
 		MaxRetries:         o.MaxRetries,
+		Username:           o.Username,
 		Password:           o.Password,
 		DialTimeout:        o.DialTimeout,
 		ReadTimeout:        o.ReadTimeout,
@@ -102,6 +130,9 @@ func (o *UniversalOptions) simple() *Options {
 		PoolTimeout:        o.PoolTimeout,
 		IdleTimeout:        o.IdleTimeout,
 		IdleCheckFrequency: o.IdleCheckFrequency,
+
+		CredentialsProvider:        o.CredentialsProvider,
+		PubSubHealthCheckFrequency: o.PubSubHealthCheckFrequency,
 	}
 }
 